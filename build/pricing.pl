@@ -67,6 +67,12 @@ use App::Options (
 			type        => 'boolean',
 			description => "Add Sustained Use Discount (SUD) [DEFAULT: 1 = yes]"
 		},
+		accelerators => {
+			required    => 1,
+			default     => '../tools/acceleratortyperegion.csv',
+			type        => '/^[a-z0-9_\.\/]+\.csv$/',
+			description => "CSV file with GCE accelerator types per region (read)"
+		},
 	},
 );
 
@@ -75,6 +81,22 @@ my $hours_month    = $App::options{hours};
 my $add_sud        = $App::options{sud};
 my $export_details = $App::options{details};
 
+# Open CSV file with accelerator types per region (acceleratortyperegion.csv)
+my $csv_accelerators = $App::options{accelerators};
+unless (-r "$csv_accelerators") { # read
+	die "ERROR: Cannot open CSV file '$csv_accelerators' for accelerator types import!\n";
+}
+my %accelerator_regions;
+open my $fh_accelerators, q{<}, "$csv_accelerators" or die "ERROR: Cannot open CSV file '$csv_accelerators'!\n";
+while (my $line = <$fh_accelerators>) {
+	chomp $line;
+	next if ($line =~ /^NAME;/); # Skip header
+	my ($accelerator, $accelerator_region) = split(';', $line);
+	next unless ($accelerator && $accelerator_region);
+	push(@{ $accelerator_regions{$accelerator} }, $accelerator_region);
+}
+close $fh_accelerators;
+
 # Open YAML file with GCP information for import (gcp.yml)
 my $yml_import = $App::options{gcp};
 unless (-r "$yml_import") { # read
@@ -122,13 +144,14 @@ SELECT
 	UNIT_DESCRIPTION,
 	SKU_ID,
 	SKU_DESCRIPTION,
-	REGIONS
+	REGIONS,
+	START_AMOUNT
 FROM skus
 WHERE MAPPING = ?
 AND REGIONS LIKE ?
 ~;
 my $sth = $dbh->prepare($sql_mapping);
-$sth->bind_columns (\my ($nanos, $units, $unit_description, $sku_id, $sku_description, $regions));
+$sth->bind_columns (\my ($nanos, $units, $unit_description, $sku_id, $sku_description, $regions, $start_amount));
 
 # &mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description)
 sub mapping_found {
@@ -182,6 +205,22 @@ sub calc_cost {
 	return $cost;
 }
 
+# &calc_tiers($value, $start_amount, $units, $nanos)
+# Returns all tiers (start usage amount and cost) of a tiered price
+sub calc_tiers {
+	my ($value, $start_amount, $units, $nanos) = @_;
+	my @bulk_start = split(',', $start_amount);
+	my @bulk_nanos = split(',', $nanos);
+	my @bulk_units = split(',', $units);
+	my @tiers;
+	foreach my $i (0 .. $#bulk_start) {
+		my $cost = $value * ( $bulk_units[$i]+($bulk_nanos[$i]*0.000000001) );
+		print "CALC: tier start = $bulk_start[$i], cost = $cost\n";
+		push(@tiers, { 'start' => $bulk_start[$i]+0, 'month' => $cost });
+	}
+	return \@tiers;
+}
+
 
 ###############################################################################
 # REGIONS
@@ -243,6 +282,7 @@ foreach my $region (@regions) {
 		&add_gcp_monitoring_data_add_cost('0-100000', $region, $cost_0_100000);
 		&add_gcp_monitoring_data_add_cost('100000-250000', $region, $cost_100000_250000);
 		&add_gcp_monitoring_data_add_cost('250000n', $region, $cost_250000n);
+		$gcp->{'monitoring'}->{'data'}->{'tiers'}->{$region} = &calc_tiers($value, $start_amount, $units, $nanos);
 		&add_gcp_monitoring_data_add_details(
 			$region,
 			$mapping,
@@ -411,6 +451,178 @@ foreach my $bucket (keys %{ $gcp->{'storage'}->{'bucket'} }) {
 }
 
 
+###############################################################################
+# BUCKET CLASS A AND CLASS B OPERATIONS PER 1,000 OPERATIONS
+###############################################################################
+
+# &add_gcp_storage_operations_cost($what, $class, $bucket, $region, $cost)
+sub add_gcp_storage_operations_cost {
+	my ($what, $class, $bucket, $region, $cost) = @_;
+	$gcp->{'storage'}->{'operations'}->{$bucket}->{$class}->{$region}->{$what}  = $cost;
+}
+
+&print_header("Bucket Storage Operations");
+foreach my $bucket (keys %{ $gcp->{'storage'}->{'bucket'} }) {
+	my $value = 1000; # per 1,000 operations
+	my @bucket_regions;
+	# Mapping
+	# https://cloud.google.com/storage/pricing#operations-pricing
+	my $mapping;
+	if    ($bucket eq 'standard')       { $mapping = 'storage.standard.ops';       @bucket_regions = @regions; }
+	elsif ($bucket eq 'standard-dual')  { $mapping = 'storage.standard.dual.ops';  @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'standard-multi') { $mapping = 'storage.standard.multi.ops'; @bucket_regions = @multi_regions; }
+	elsif ($bucket eq 'nearline')       { $mapping = 'storage.nearline.ops';       @bucket_regions = @regions; }
+	elsif ($bucket eq 'nearline-dual')  { $mapping = 'storage.nearline.ops';       @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'nearline-multi') { $mapping = 'storage.nearline.ops';       @bucket_regions = @multi_regions; }
+	elsif ($bucket eq 'coldline')       { $mapping = 'storage.coldline.ops';       @bucket_regions = @regions; }
+	elsif ($bucket eq 'coldline-dual')  { $mapping = 'storage.coldline.ops';       @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'coldline-multi') { $mapping = 'storage.coldline.ops';       @bucket_regions = @multi_regions; }
+	elsif ($bucket eq 'archiv')         { $mapping = 'storage.archive.ops';        @bucket_regions = @regions; }
+	elsif ($bucket eq 'archiv-dual')    { $mapping = 'storage.archive.ops';        @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'archiv-multi')   { $mapping = 'storage.archive.ops';        @bucket_regions = @multi_regions; }
+	elsif ($bucket eq 'dra')            { $mapping = 'storage.standard.ops';       @bucket_regions = @regions; } # Same as standard
+	elsif ($bucket eq 'dra-dual')       { $mapping = 'storage.standard.dual.ops';  @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'dra-multi')      { $mapping = 'storage.standard.multi.ops'; @bucket_regions = @multi_regions; }
+	# Unknown storage type
+	else { die "ERROR: No operations mapping for storage bucket '$bucket'!\n"; }
+	foreach my $region (@bucket_regions) {
+		foreach my $class ('class-a', 'class-b') {
+			my $class_mapping = "$mapping." . ($class eq 'class-a' ? 'a' : 'b');
+			print "Bucket: $bucket\n";
+			print "MAPPING: '$class_mapping' in region '$region'\n";
+			$sth->execute($class_mapping, 'global'); # Search SKU(s)
+			my $found = 0;
+			while ($sth->fetch) {
+				&mapping_found($class_mapping, 'global', $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+				# Check duplicate entries for mapping and region
+				if ($found) {
+					die "ERROR: Duplicate entry. Already found price for this mapping '$class_mapping' in region '$region'!\n"
+				} else {
+					$found = 1;
+					my $cost = &calc_cost($value, $units, $nanos);
+					&add_gcp_storage_operations_cost('month', $class, $bucket, $region, $cost);
+				}
+			}
+			$sth->finish;
+			unless ($found) {
+				warn "WARNING: '$class_mapping' not found in region '$region'!\n";
+			}
+		}
+	}
+}
+
+###############################################################################
+# BUCKET EARLY DELETION GB PER MONTH
+###############################################################################
+
+# &add_gcp_storage_early_deletion_cost($what, $bucket, $region, $cost)
+sub add_gcp_storage_early_deletion_cost {
+	my ($what, $bucket, $region, $cost) = @_;
+	$gcp->{'storage'}->{'early-deletion'}->{$bucket}->{'cost'}->{$region}->{$what}  = $cost;
+}
+
+&print_header("Bucket Storage Early Deletion");
+foreach my $bucket (keys %{ $gcp->{'storage'}->{'bucket'} }) {
+	my $value = 1; # 1 GB per month
+	my @bucket_regions;
+	# Mapping and minimum storage duration in days
+	# https://cloud.google.com/storage/pricing#early-delete
+	my ($mapping, $days);
+	if    ($bucket eq 'nearline')       { $mapping = 'storage.nearline.early';       $days = 30;  @bucket_regions = @regions; }
+	elsif ($bucket eq 'nearline-dual')  { $mapping = 'storage.nearline.dual.early';  $days = 30;  @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'nearline-multi') { $mapping = 'storage.nearline.multi.early'; $days = 30;  @bucket_regions = @multi_regions; }
+	elsif ($bucket eq 'coldline')       { $mapping = 'storage.coldline.early';       $days = 90;  @bucket_regions = @regions; }
+	elsif ($bucket eq 'coldline-dual')  { $mapping = 'storage.coldline.dual.early';  $days = 90;  @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'coldline-multi') { $mapping = 'storage.coldline.multi.early'; $days = 90;  @bucket_regions = @multi_regions; }
+	elsif ($bucket eq 'archiv')         { $mapping = 'storage.archive.early';        $days = 365; @bucket_regions = @regions; }
+	elsif ($bucket eq 'archiv-dual')    { $mapping = 'storage.archive.dual.early';   $days = 365; @bucket_regions = @dual_regions; }
+	elsif ($bucket eq 'archiv-multi')   { $mapping = 'storage.archive.multi.early';  $days = 365; @bucket_regions = @multi_regions; }
+	# No minimum storage duration
+	else { next; }
+	$gcp->{'storage'}->{'early-deletion'}->{$bucket}->{'days'} = $days;
+	foreach my $region (@bucket_regions) {
+		print "Bucket: $bucket\n";
+		print "MAPPING: '$mapping' in region '$region'\n";
+		$sth->execute("$mapping", '%'."$region".'%'); # Search SKU(s)
+		my $found = 0;
+		while ($sth->fetch) {
+			if (&check_region($region, $regions)) {
+				&mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+				# Check duplicate entries for mapping and region
+				if ($found) {
+					die "ERROR: Duplicate entry. Already found price for this mapping '$mapping' in region '$region'!\n"
+				} else {
+					$found = 1;
+					my $cost = &calc_cost($value, $units, $nanos);
+					&add_gcp_storage_early_deletion_cost('month', $bucket, $region, $cost);
+				}
+			}
+		}
+		$sth->finish;
+		unless ($found) {
+			warn "WARNING: '$mapping' not found in region '$region'!\n";
+		}
+	}
+}
+
+###############################################################################
+# BUCKET REPLICATION GB
+###############################################################################
+
+# &add_gcp_storage_replication_cost($what, $bucket, $region, $cost)
+sub add_gcp_storage_replication_cost {
+	my ($what, $bucket, $region, $cost) = @_;
+	$gcp->{'storage'}->{'replication'}->{$bucket}->{'cost'}->{$region}->{$what}  = $cost;
+}
+
+# Continent in the SKU description of the replication
+my %replication_continents = (
+	'asia'             => 'Asia',
+	'europe'           => 'Europe',
+	'northern-america' => 'Northern America',
+);
+
+&print_header("Bucket Storage Replication");
+foreach my $bucket (keys %{ $gcp->{'storage'}->{'bucket'} }) {
+	my $value = 1; # 1 GB
+	my $mapping = 'storage.replication';
+	# Only dual-region and multi-region buckets are replicated
+	# https://cloud.google.com/storage/pricing#network-pricing
+	my ($location, @bucket_regions);
+	if    ($bucket =~ m/-dual$/)  { $location = 'dual-region';  @bucket_regions = @dual_regions; }
+	elsif ($bucket =~ m/-multi$/) { $location = 'multi-region'; @bucket_regions = @multi_regions; }
+	else { next; }
+	foreach my $region (@bucket_regions) {
+		print "Bucket: $bucket\n";
+		print "MAPPING: '$mapping' in region '$region'\n";
+		my $continent = $replication_continents{ $gcp->{$location}->{$region}->{'continent'} || '' } || '';
+		unless ($continent) {
+			warn "WARNING: Continent of '$region' not found!\n";
+			next;
+		}
+		$sth->execute($mapping, 'global'); # Search SKU(s)
+		my $found = 0;
+		while ($sth->fetch) {
+			# Network Data Transfer GCP Replication within Europe
+			next unless ($sku_description =~ m/within $continent$/);
+			&mapping_found($mapping, 'global', $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+			# Check duplicate entries for mapping and region
+			if ($found) {
+				die "ERROR: Duplicate entry. Already found price for this mapping '$mapping' in region '$region'!\n"
+			} else {
+				$found = 1;
+				my $cost = &calc_cost($value, $units, $nanos);
+				&add_gcp_storage_replication_cost('month', $bucket, $region, $cost);
+			}
+		}
+		$sth->finish;
+		unless ($found) {
+			warn "WARNING: '$mapping' not found in region '$region'!\n";
+		}
+	}
+}
+
+
 ###############################################################################
 # DISK STORAGE GB PER MONTH
 ###############################################################################
@@ -431,6 +643,12 @@ sub add_gcp_compute_storage_details {
 	$gcp->{'compute'}->{'storage'}->{$disk}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'description'} = $sku_description;
 }
 
+# &add_gcp_compute_storage_provisioned_cost($what, $provisioned, $disk, $region, $cost)
+sub add_gcp_compute_storage_provisioned_cost {
+	my ($what, $provisioned, $disk, $region, $cost) = @_;
+	$gcp->{'compute'}->{'storage'}->{$disk}->{$provisioned}->{'cost'}->{$region}->{$what} = $cost;
+}
+
 &print_header("Disk Storage");
 foreach my $disk (keys %{ $gcp->{'compute'}->{'storage'} }) {
 	my $value = 1; # 1 GB per month
@@ -453,8 +671,12 @@ foreach my $disk (keys %{ $gcp->{'compute'}->{'storage'} }) {
 		elsif ($disk eq 'balanced')            { $mapping = 'gce.storage.ssd.balanced'; }
 		# Zonal extreme PD
 		elsif ($disk eq 'extreme')             { $mapping = 'gce.storage.ssd.extreme'; }
+		# Hyperdisk Balanced
+		elsif ($disk eq 'hyperdisk-balanced')  { $mapping = 'gce.storage.hyperdisk.balanced'; }
 		# Hyperdisk Extreme
 		elsif ($disk eq 'hyperdisk-extreme')   { $mapping = 'gce.storage.hyperdisk.extreme'; }
+		# Hyperdisk Throughput
+		elsif ($disk eq 'hyperdisk-throughput') { $mapping = 'gce.storage.hyperdisk.throughput'; }
 		# Regional standard PD
 		elsif ($disk eq 'hdd-replicated')      { $mapping = 'gce.storage.hdd.replicated'; }
 		# Regional SSD PD
@@ -590,6 +812,31 @@ foreach my $disk (keys %{ $gcp->{'compute'}->{'storage'} }) {
 				}
 			}
 			$sth->finish;
+			# Provisioned IOPS and throughput
+			foreach my $provisioned ('iops', 'throughput') {
+				next unless ($gcp->{'compute'}->{'storage'}->{$disk}->{$provisioned}->{'max'});
+				print "Check provisioned $provisioned:\n";
+				my $provisioned_found = 0;
+				my $mapping_provisioned = "$mapping".'.'."$provisioned";
+				$sth->execute($mapping_provisioned, '%'."$region".'%'); # Search SKU(s)
+				while ($sth->fetch) {
+					if (&check_region($region, $regions)) {
+						&mapping_found($mapping_provisioned, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+						# Check duplicate entries for mapping and region
+						if ($provisioned_found) {
+							die "ERROR: Duplicate entry. Already found $provisioned price for this mapping '$mapping_provisioned' in region '$region'!\n"
+						} else {
+							$provisioned_found = 1;
+							my $cost = &calc_cost($value, $units, $nanos);
+							&add_gcp_compute_storage_provisioned_cost('month', $provisioned, $disk, $region, $cost); # per IOPS or MiB/s
+						}
+					}
+				}
+				$sth->finish;
+				unless ($provisioned_found) {
+					warn "WARNING: '$mapping_provisioned' not found in region '$region'!\n";
+				}
+			}
 		} else {
 			warn "WARNING: '$mapping' not found in region '$region'!\n";
 		}
@@ -644,6 +891,7 @@ foreach my $region (@regions) {
 
 		# Sustained Use Discount
 		# https://cloud.google.com/compute/docs/sustained-use-discounts
+		# Authoritative for gcosts (gcosts/pricing/sud.go), TestSustainedUseDiscountPricingPl checks that both agree
 		my %sustained_use_discount = (
 			1 => 1, # 0%–25%
 			2 => 1, # 25%–50%
@@ -1680,6 +1928,570 @@ foreach my $machine (keys %{ $gcp->{'compute'}->{'instance'} }) {
 }
 
 
+###############################################################################
+# GPUS
+###############################################################################
+
+# &add_gcp_compute_gpu_cost($what, $gpu, $region, $cost)
+sub add_gcp_compute_gpu_cost {
+	my ($what, $gpu, $region, $cost) = @_;
+	$gcp->{'compute'}->{'gpu'}->{$gpu}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_compute_gpu_details($gpu, $region, $mapping, $sku_id, $value, $nanos, $units, $unit_description, $sku_description)
+sub add_gcp_compute_gpu_details {
+	my ($gpu, $region, $mapping, $sku_id, $value, $nanos, $units, $unit_description, $sku_description) = @_;
+	$gcp->{'compute'}->{'gpu'}->{$gpu}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'sku'}   = $sku_id;
+	$gcp->{'compute'}->{'gpu'}->{$gpu}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'value'} = $value;
+	$gcp->{'compute'}->{'gpu'}->{$gpu}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'nanos'} = $nanos;
+	$gcp->{'compute'}->{'gpu'}->{$gpu}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'units'} = $units;
+	$gcp->{'compute'}->{'gpu'}->{$gpu}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'unit'} = $unit_description;
+	$gcp->{'compute'}->{'gpu'}->{$gpu}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'description'} = $sku_description;
+}
+
+&print_header("GPUs");
+# Mapping of the GCE accelerator types
+# https://cloud.google.com/compute/docs/gpus
+my %gpu_mappings = (
+	'nvidia-tesla-k80'      => 'gce.compute.gpu.k80',
+	'nvidia-tesla-p4'       => 'gce.compute.gpu.p4',
+	'nvidia-tesla-p100'     => 'gce.compute.gpu.p100',
+	'nvidia-tesla-t4'       => 'gce.compute.gpu.t4',
+	'nvidia-tesla-v100'     => 'gce.compute.gpu.v100',
+	'nvidia-tesla-a100'     => 'gce.compute.gpu.a100',
+	'nvidia-a100-80gb'      => 'gce.compute.gpu.a100.80gb',
+	'nvidia-l4'             => 'gce.compute.gpu.l4',
+	'nvidia-h100-80gb'      => 'gce.compute.gpu.h100.80gb',
+	'nvidia-h100-mega-80gb' => 'gce.compute.gpu.h100.80gb.mega',
+	'nvidia-rtx-pro-6000'   => 'gce.compute.gpu.rtx6000',
+);
+# GPUs attached to N1 machine types get the sustained use discount of N1
+my %gpu_sustained_use_discount = (
+	1 => 1,   # 0%–25%   = 100% of base rate
+	2 => 0.8, # 25%–50%  = 80% of base rate
+	3 => 0.6, # 50%–75%  = 60% of base rate
+	4 => 0.4, # 75%–100% = 40% of base rate
+);
+foreach my $gpu (sort keys %gpu_mappings) {
+	my $mapping = $gpu_mappings{$gpu};
+	# Only regions in which the GPU is available
+	my @gpu_regions = @{ $accelerator_regions{$gpu} || [] };
+	unless (@gpu_regions) {
+		warn "WARNING: GPU '$gpu' not available in any region!\n";
+		next;
+	}
+	my $sud = 1;
+	if ($add_sud && $gpu =~ m/^nvidia-tesla-(k80|p4|p100|t4|v100)$/) {
+		$sud = 0;
+		foreach my $usage_level (keys %gpu_sustained_use_discount) {
+			$sud += $gpu_sustained_use_discount{$usage_level} / scalar(keys %gpu_sustained_use_discount);
+		}
+	}
+	print "GPU: $gpu\n";
+	foreach my $region (@regions) {
+		next unless (grep { $_ eq $region } @gpu_regions);
+		my $value = 1; # per GPU and hour
+		print "MAPPING: '$mapping' in region '$region'\n";
+		my $found = 0;
+		$sth->execute($mapping, '%'."$region".'%'); # Search SKU(s)
+		while ($sth->fetch) {
+			if (&check_region($region, $regions)) {
+				&mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+				$found = 1;
+				my $cost = &calc_cost($value, $units, $nanos);
+				&add_gcp_compute_gpu_cost('hour', $gpu, $region, $cost);
+				&add_gcp_compute_gpu_cost('month', $gpu, $region, $cost*$hours_month*$sud);
+				&add_gcp_compute_gpu_details(
+					$gpu,
+					$region,
+					$mapping,
+					$sku_id,
+					$value,
+					$nanos,
+					$units,
+					$unit_description,
+					$sku_description
+				) if ($export_details);
+			}
+		}
+		$sth->finish;
+		unless ($found) {
+			warn "WARNING: GPU '$mapping' not found in region '$region'!\n";
+			next;
+		}
+		push(@{ $gcp->{'compute'}->{'gpu'}->{$gpu}->{'regions'} }, $region);
+		# Commitments and Spot VMs
+		foreach my $what ('1y', '3y', 'spot') {
+			my $mapping_what = "$mapping".'.'."$what";
+			$sth->execute($mapping_what, '%'."$region".'%'); # Search SKU(s)
+			while ($sth->fetch) {
+				if (&check_region($region, $regions)) {
+					&mapping_found($mapping_what, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+					my $cost = &calc_cost($value, $units, $nanos);
+					&add_gcp_compute_gpu_cost('hour_spot', $gpu, $region, $cost) if ($what eq 'spot');
+					&add_gcp_compute_gpu_cost('month_'."$what", $gpu, $region, $cost*$hours_month);
+					&add_gcp_compute_gpu_details(
+						$gpu,
+						$region,
+						$mapping_what,
+						$sku_id,
+						$value,
+						$nanos,
+						$units,
+						$unit_description,
+						$sku_description
+					) if ($export_details);
+				}
+			}
+			$sth->finish;
+		}
+	}
+}
+
+
+###############################################################################
+# CUSTOM MACHINE TYPES
+###############################################################################
+
+# &add_gcp_compute_custom_cost($what, $family, $resource, $region, $cost)
+sub add_gcp_compute_custom_cost {
+	my ($what, $family, $resource, $region, $cost) = @_;
+	$gcp->{'compute'}->{'custom'}->{$family}->{$resource}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_compute_custom_details($family, $resource, $region, $mapping, $sku_id, $value, $nanos, $units, $unit_description, $sku_description)
+sub add_gcp_compute_custom_details {
+	my ($family, $resource, $region, $mapping, $sku_id, $value, $nanos, $units, $unit_description, $sku_description) = @_;
+	$gcp->{'compute'}->{'custom'}->{$family}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'sku'}   = $sku_id;
+	$gcp->{'compute'}->{'custom'}->{$family}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'value'} = $value;
+	$gcp->{'compute'}->{'custom'}->{$family}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'nanos'} = $nanos;
+	$gcp->{'compute'}->{'custom'}->{$family}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'units'} = $units;
+	$gcp->{'compute'}->{'custom'}->{$family}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'unit'} = $unit_description;
+	$gcp->{'compute'}->{'custom'}->{$family}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'description'} = $sku_description;
+}
+
+&print_header("Custom machine types");
+# Price per vCPU and per GiB memory of the custom machine types
+# https://cloud.google.com/compute/docs/instances/creating-instance-with-custom-machine-type
+# Mappings: on-demand, 1 year commitment, 3 year commitment, Spot VM
+my %custom_mappings = (
+	'n1' => {
+		'cpu'          => ['gce.compute.cpu.n1.custom', 'gce.compute.cpu.n1.1y', 'gce.compute.cpu.n1.3y', 'gce.compute.cpu.n1.custom.spot'],
+		'ram'          => ['gce.compute.ram.n1.custom', 'gce.compute.ram.n1.1y', 'gce.compute.ram.n1.3y', 'gce.compute.ram.n1.custom.spot'],
+		'ram-extended' => ['gce.compute.ram.n1.custom.extended', '', '', 'gce.compute.ram.n1.custom.extended.spot'],
+	},
+	'n2' => {
+		'cpu'          => ['gce.compute.cpu.n2.custom', 'gce.compute.cpu.n2.1y', 'gce.compute.cpu.n2.3y', 'gce.compute.cpu.n2.custom.spot'],
+		'ram'          => ['gce.compute.ram.n2.custom', 'gce.compute.ram.n2.1y', 'gce.compute.ram.n2.3y', 'gce.compute.ram.n2.custom.spot'],
+		'ram-extended' => ['gce.compute.ram.n2.custom.extended', '', '', 'gce.compute.ram.n2.custom.extended.spot'],
+	},
+	'n2d' => {
+		'cpu'          => ['gce.compute.cpu.n2d.custom', 'gce.compute.cpu.n2d.1y', 'gce.compute.cpu.n2d.3y', 'gce.compute.cpu.n2d.custom.spot'],
+		'ram'          => ['gce.compute.ram.n2d.custom', 'gce.compute.ram.n2d.1y', 'gce.compute.ram.n2d.3y', 'gce.compute.ram.n2d.custom.spot'],
+		'ram-extended' => ['gce.compute.ram.n2d.custom.extended', '', '', 'gce.compute.ram.n2d.custom.extended.spot'],
+	},
+	'n4' => {
+		'cpu'          => ['gce.compute.cpu.n4.custom', 'gce.compute.cpu.n4.1y', 'gce.compute.cpu.n4.3y', 'gce.compute.cpu.n4.custom.spot'],
+		'ram'          => ['gce.compute.ram.n4.custom', 'gce.compute.ram.n4.1y', 'gce.compute.ram.n4.3y', 'gce.compute.ram.n4.custom.spot'],
+	},
+	# E2 custom machine types have the same price as E2 predefined machine types
+	'e2' => {
+		'cpu'          => ['gce.compute.cpu.e2', 'gce.compute.cpu.e2.1y', 'gce.compute.cpu.e2.3y', 'gce.compute.cpu.e2.spot'],
+		'ram'          => ['gce.compute.ram.e2', 'gce.compute.ram.e2.1y', 'gce.compute.ram.e2.3y', 'gce.compute.ram.e2.spot'],
+	},
+);
+# Average sustained use discount for the full month
+my %custom_sustained_use_discount = (
+	'n1'  => (1+0.8+0.6+0.4)/4,
+	'n2'  => (1+0.8678+0.733+0.6)/4,
+	'n2d' => (1+0.8678+0.733+0.6)/4,
+);
+foreach my $family (sort keys %custom_mappings) {
+	my $sud = 1;
+	$sud = $custom_sustained_use_discount{$family} if ($add_sud && $custom_sustained_use_discount{$family});
+	foreach my $resource (sort keys %{ $custom_mappings{$family} }) {
+		my ($mapping, $mapping_1y, $mapping_3y, $mapping_spot) = @{ $custom_mappings{$family}{$resource} };
+		print "Custom machine type: $family $resource\n";
+		foreach my $region (@regions) {
+			my $value = 1; # per vCPU or GiB and hour
+			foreach my $what ('month', 'month_1y', 'month_3y', 'month_spot') {
+				my $mapping_what = $mapping;
+				$mapping_what = $mapping_1y   if ($what eq 'month_1y');
+				$mapping_what = $mapping_3y   if ($what eq 'month_3y');
+				$mapping_what = $mapping_spot if ($what eq 'month_spot');
+				next unless ($mapping_what);
+				print "MAPPING: '$mapping_what' in region '$region'\n";
+				my $found = 0;
+				$sth->execute($mapping_what, '%'."$region".'%'); # Search SKU(s)
+				while ($sth->fetch) {
+					next if ($found);
+					if (&check_region($region, $regions)) {
+						&mapping_found($mapping_what, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+						$found = 1;
+						my $cost = &calc_cost($value, $units, $nanos);
+						if ($what eq 'month') {
+							&add_gcp_compute_custom_cost('hour', $family, $resource, $region, $cost);
+							&add_gcp_compute_custom_cost('month', $family, $resource, $region, $cost*$hours_month*$sud);
+						} elsif ($what eq 'month_spot') {
+							&add_gcp_compute_custom_cost('hour_spot', $family, $resource, $region, $cost);
+							&add_gcp_compute_custom_cost('month_spot', $family, $resource, $region, $cost*$hours_month);
+						} else {
+							&add_gcp_compute_custom_cost($what, $family, $resource, $region, $cost*$hours_month);
+						}
+						&add_gcp_compute_custom_details(
+							$family,
+							$resource,
+							$region,
+							$mapping_what,
+							$sku_id,
+							$value,
+							$nanos,
+							$units,
+							$unit_description,
+							$sku_description
+						) if ($export_details);
+					}
+				}
+				$sth->finish;
+				warn "WARNING: Custom machine type '$mapping_what' not found in region '$region'!\n" unless ($found);
+			}
+		}
+	}
+}
+
+
+###############################################################################
+# GOOGLE KUBERNETES ENGINE
+###############################################################################
+
+# &add_gcp_gke_cluster_cost($what, $region, $cost)
+sub add_gcp_gke_cluster_cost {
+	my ($what, $region, $cost) = @_;
+	$gcp->{'gke'}->{'cluster'}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_gke_autopilot_cost($what, $resource, $region, $cost)
+sub add_gcp_gke_autopilot_cost {
+	my ($what, $resource, $region, $cost) = @_;
+	$gcp->{'gke'}->{'autopilot'}->{$resource}->{'cost'}->{$region}->{$what} = $cost;
+}
+
+&print_header("Google Kubernetes Engine");
+# Cluster management fee per cluster and hour (Standard and Autopilot)
+# https://cloud.google.com/kubernetes-engine/pricing#cluster_management_fee_and_free_tier
+foreach my $region (@regions) {
+	my $value = 1; # per hour
+	my $mapping = 'gke.cluster';
+	print "MAPPING: '$mapping' in region '$region'\n";
+	my $found = 0;
+	$sth->execute($mapping, 'global'); # Search SKU(s)
+	while ($sth->fetch) {
+		next if ($found);
+		&mapping_found($mapping, 'global', $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+		$found = 1;
+		my $cost = &calc_cost($value, $units, $nanos);
+		&add_gcp_gke_cluster_cost('hour', $region, $cost);
+		&add_gcp_gke_cluster_cost('month', $region, $cost*$hours_month);
+	}
+	$sth->finish;
+	warn "WARNING: '$mapping' not found in region '$region'!\n" unless ($found);
+}
+# Autopilot pod requests per vCPU, GiB memory and GiB ephemeral storage and hour
+# https://cloud.google.com/kubernetes-engine/pricing#autopilot_mode
+# Mappings: on-demand, 1 year commitment, 3 year commitment, Spot Pods
+my %autopilot_mappings = (
+	'cpu'     => ['gke.autopilot.cpu', 'gke.autopilot.cpu.1y', 'gke.autopilot.cpu.3y', 'gke.autopilot.cpu.spot'],
+	'ram'     => ['gke.autopilot.ram', 'gke.autopilot.ram.1y', 'gke.autopilot.ram.3y', 'gke.autopilot.ram.spot'],
+	'storage' => ['gke.autopilot.storage', 'gke.autopilot.storage.1y', 'gke.autopilot.storage.3y', 'gke.autopilot.storage.spot'],
+);
+foreach my $resource (sort keys %autopilot_mappings) {
+	my ($mapping, $mapping_1y, $mapping_3y, $mapping_spot) = @{ $autopilot_mappings{$resource} };
+	print "Autopilot: $resource\n";
+	foreach my $region (@regions) {
+		my $value = 1; # per vCPU or GiB and hour
+		foreach my $what ('month', 'month_1y', 'month_3y', 'month_spot') {
+			my $mapping_what = $mapping;
+			$mapping_what = $mapping_1y   if ($what eq 'month_1y');
+			$mapping_what = $mapping_3y   if ($what eq 'month_3y');
+			$mapping_what = $mapping_spot if ($what eq 'month_spot');
+			print "MAPPING: '$mapping_what' in region '$region'\n";
+			my $found = 0;
+			$sth->execute($mapping_what, '%'."$region".'%'); # Search SKU(s)
+			while ($sth->fetch) {
+				next if ($found);
+				if (&check_region($region, $regions)) {
+					&mapping_found($mapping_what, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+					$found = 1;
+					my $cost = &calc_cost($value, $units, $nanos);
+					if ($what eq 'month') {
+						&add_gcp_gke_autopilot_cost('hour', $resource, $region, $cost);
+						&add_gcp_gke_autopilot_cost('month', $resource, $region, $cost*$hours_month);
+					} elsif ($what eq 'month_spot') {
+						&add_gcp_gke_autopilot_cost('hour_spot', $resource, $region, $cost);
+						&add_gcp_gke_autopilot_cost('month_spot', $resource, $region, $cost*$hours_month);
+					} else {
+						&add_gcp_gke_autopilot_cost($what, $resource, $region, $cost*$hours_month);
+					}
+				}
+			}
+			$sth->finish;
+			# Not all resources have commitments or Spot prices
+			warn "WARNING: Autopilot '$mapping_what' not found in region '$region'!\n" unless ($found || $what ne 'month');
+		}
+	}
+}
+
+
+###############################################################################
+# CLOUD SQL
+###############################################################################
+
+# &add_gcp_sql_edition_cost($what, $edition, $resource, $region, $cost)
+sub add_gcp_sql_edition_cost {
+	my ($what, $edition, $resource, $region, $cost) = @_;
+	$gcp->{'sql'}->{'edition'}->{$edition}->{$resource}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_sql_tier_cost($what, $tier, $region, $cost)
+sub add_gcp_sql_tier_cost {
+	my ($what, $tier, $region, $cost) = @_;
+	$gcp->{'sql'}->{'tier'}->{$tier}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_sql_license_cost($what, $license, $region, $cost)
+sub add_gcp_sql_license_cost {
+	my ($what, $license, $region, $cost) = @_;
+	$gcp->{'sql'}->{'license'}->{$license}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_sql_storage_cost($what, $storage, $region, $cost)
+sub add_gcp_sql_storage_cost {
+	my ($what, $storage, $region, $cost) = @_;
+	$gcp->{'sql'}->{'storage'}->{$storage}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &find_gcp_sql_cost($mapping, $region, $value)
+# Returns the cost of the first SKU found for the mapping in the region or undef
+sub find_gcp_sql_cost {
+	my ($mapping, $region, $value) = @_;
+	print "MAPPING: '$mapping' in region '$region'\n";
+	my $cost;
+	$sth->execute($mapping, '%'."$region".'%'); # Search SKU(s)
+	while ($sth->fetch) {
+		next if (defined $cost);
+		if (&check_region($region, $regions)) {
+			&mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+			$cost = &calc_cost($value, $units, $nanos);
+		}
+	}
+	$sth->finish;
+	warn "WARNING: '$mapping' not found in region '$region'!\n" unless (defined $cost);
+	return $cost;
+}
+
+&print_header("Cloud SQL");
+# Committed use discounts of Cloud SQL are spend-based
+# https://cloud.google.com/sql/cud
+my $sql_cud_1y = 0.75; # 25% discount
+my $sql_cud_3y = 0.48; # 52% discount
+# Enterprise and Enterprise Plus edition per vCPU and GiB memory and hour
+# MySQL, PostgreSQL and SQL Server have the same price for vCPU and memory
+# https://cloud.google.com/sql/pricing#instance-pricing
+my %sql_edition_mappings = (
+	'enterprise'      => { 'cpu' => 'sql.enterprise.cpu',      'ram' => 'sql.enterprise.ram' },
+	'enterprise-plus' => { 'cpu' => 'sql.enterprise-plus.cpu', 'ram' => 'sql.enterprise-plus.ram' },
+);
+foreach my $edition (sort keys %sql_edition_mappings) {
+	print "Edition: $edition\n";
+	foreach my $resource ('cpu', 'ram') {
+		my $mapping = $sql_edition_mappings{$edition}->{$resource};
+		foreach my $region (@regions) {
+			my $cost = &find_gcp_sql_cost($mapping, $region, 1); # per vCPU or GiB and hour
+			next unless (defined $cost);
+			&add_gcp_sql_edition_cost('hour',     $edition, $resource, $region, $cost);
+			&add_gcp_sql_edition_cost('month',    $edition, $resource, $region, $cost*$hours_month);
+			&add_gcp_sql_edition_cost('month_1y', $edition, $resource, $region, $cost*$hours_month*$sql_cud_1y);
+			&add_gcp_sql_edition_cost('month_3y', $edition, $resource, $region, $cost*$hours_month*$sql_cud_3y);
+		}
+	}
+}
+# Shared-core tiers per instance and hour
+foreach my $tier (sort keys %{ $gcp->{'sql'}->{'tier'} }) {
+	print "Tier: $tier\n";
+	my $mapping = "sql.$tier";
+	foreach my $region (@regions) {
+		my $cost = &find_gcp_sql_cost($mapping, $region, 1); # per hour
+		next unless (defined $cost);
+		&add_gcp_sql_tier_cost('hour',  $tier, $region, $cost);
+		&add_gcp_sql_tier_cost('month', $tier, $region, $cost*$hours_month);
+	}
+}
+# SQL Server licenses per vCPU and hour (Express edition is free)
+# https://cloud.google.com/sql/pricing#sqlserver
+foreach my $license ('standard', 'enterprise', 'web') {
+	print "SQL Server license: $license\n";
+	my $mapping = "sql.sqlserver.$license";
+	foreach my $region (@regions) {
+		my $cost = &find_gcp_sql_cost($mapping, $region, 1); # per vCPU and hour
+		next unless (defined $cost);
+		&add_gcp_sql_license_cost('hour',  $license, $region, $cost);
+		&add_gcp_sql_license_cost('month', $license, $region, $cost*$hours_month);
+	}
+}
+# Storage (SSD and HDD) and backups per GiB and month
+# https://cloud.google.com/sql/pricing#storage-networking-prices
+foreach my $storage ('ssd', 'hdd', 'backup') {
+	print "Storage: $storage\n";
+	my $mapping = "sql.storage.$storage";
+	foreach my $region (@regions) {
+		my $cost = &find_gcp_sql_cost($mapping, $region, 1); # per GiB and month
+		next unless (defined $cost);
+		&add_gcp_sql_storage_cost('month', $storage, $region, $cost);
+	}
+}
+
+
+###############################################################################
+# CLOUD RUN
+###############################################################################
+
+# &add_gcp_serverless_cost($what, $billing, $resource, $region, $cost)
+sub add_gcp_serverless_cost {
+	my ($what, $billing, $resource, $region, $cost) = @_;
+	$gcp->{'serverless'}->{$billing}->{$resource}->{'cost'}->{$region}->{$what} = $cost;
+}
+
+&print_header("Cloud Run");
+# Regions with tier 1 pricing, all other regions have tier 2 pricing
+# https://cloud.google.com/run/pricing#tables
+my %serverless_tier1_regions = map { $_ => 1 } (
+	'asia-east1', 'asia-northeast1', 'asia-northeast2',
+	'europe-north1', 'europe-southwest1', 'europe-west1', 'europe-west4', 'europe-west8', 'europe-west9',
+	'me-west1',
+	'us-central1', 'us-east1', 'us-east4', 'us-east5', 'us-south1', 'us-west1',
+);
+# Billing models and resources with the value of the price
+# vCPU and memory per second (value 3600 for the price per hour), requests per 1 million
+my %serverless_resources = (
+	'request'  => { 'cpu' => 3600, 'ram' => 3600, 'requests' => 1000000, 'idle-cpu' => 3600, 'idle-ram' => 3600 },
+	'instance' => { 'cpu' => 3600, 'ram' => 3600 },
+);
+foreach my $billing (sort keys %serverless_resources) {
+	print "Billing: $billing\n";
+	foreach my $resource (sort keys %{ $serverless_resources{$billing} }) {
+		my $value = $serverless_resources{$billing}->{$resource};
+		foreach my $region (@regions) {
+			my $tier = $serverless_tier1_regions{$region} ? 'tier1' : 'tier2';
+			my $mapping = "run.$billing.$resource.$tier";
+			print "MAPPING: '$mapping' in region '$region'\n";
+			my $found = 0;
+			$sth->execute($mapping, 'global'); # Search SKU(s)
+			while ($sth->fetch) {
+				next if ($found);
+				&mapping_found($mapping, 'global', $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+				$found = 1;
+				my $cost = &calc_cost($value, $units, $nanos);
+				if ($resource eq 'requests') {
+					&add_gcp_serverless_cost('month', $billing, $resource, $region, $cost);
+				} else {
+					&add_gcp_serverless_cost('hour',  $billing, $resource, $region, $cost);
+					&add_gcp_serverless_cost('month', $billing, $resource, $region, $cost*$hours_month);
+				}
+			}
+			$sth->finish;
+			warn "WARNING: '$mapping' not found in region '$region'!\n" unless ($found);
+		}
+	}
+}
+
+
+###############################################################################
+# BIGQUERY
+###############################################################################
+
+# &add_gcp_bigquery_cost($what, $resource, $region, $cost)
+sub add_gcp_bigquery_cost {
+	my ($what, $resource, $region, $cost) = @_;
+	$gcp->{'bigquery'}->{$resource}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_bigquery_type_cost($what, $resource, $type, $region, $cost)
+sub add_gcp_bigquery_type_cost {
+	my ($what, $resource, $type, $region, $cost) = @_;
+	$gcp->{'bigquery'}->{$resource}->{$type}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &find_gcp_bigquery_cost($mapping, $region, $value)
+# Returns the cost of the first SKU found for the mapping in the region or multi-region or undef
+sub find_gcp_bigquery_cost {
+	my ($mapping, $region, $value) = @_;
+	print "MAPPING: '$mapping' in region '$region'\n";
+	my $cost;
+	$sth->execute($mapping, '%'."$region".'%'); # Search SKU(s)
+	while ($sth->fetch) {
+		next if (defined $cost);
+		if (&check_region($region, $regions)) {
+			&mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+			$cost = &calc_cost($value, $units, $nanos);
+		}
+	}
+	$sth->finish;
+	return $cost;
+}
+
+&print_header("BigQuery");
+# BigQuery locations are regions and the multi-regions US and EU
+# https://cloud.google.com/bigquery/docs/locations
+my @bigquery_regions = (@regions, @multi_regions);
+# On-demand analysis per TiB scanned
+# https://cloud.google.com/bigquery/pricing#on_demand_pricing
+foreach my $region (@bigquery_regions) {
+	my $mapping = 'bigquery.analysis';
+	my $cost = &find_gcp_bigquery_cost($mapping, $region, 1); # per TiB
+	unless (defined $cost) {
+		warn "WARNING: '$mapping' not found in region '$region'!\n";
+		next;
+	}
+	&add_gcp_bigquery_cost('month', 'analysis', $region, $cost);
+}
+# Editions per slot and hour with 1 and 3 year commitments (Enterprise and Enterprise Plus)
+# https://cloud.google.com/bigquery/pricing#capacity_compute_analysis_pricing
+foreach my $edition ('standard', 'enterprise', 'enterprise-plus') {
+	print "Edition: $edition\n";
+	foreach my $region (@bigquery_regions) {
+		my $mapping = "bigquery.$edition";
+		my $cost = &find_gcp_bigquery_cost($mapping, $region, 1); # per slot and hour
+		unless (defined $cost) {
+			warn "WARNING: '$mapping' not found in region '$region'!\n";
+			next;
+		}
+		&add_gcp_bigquery_type_cost('hour',  'edition', $edition, $region, $cost);
+		&add_gcp_bigquery_type_cost('month', 'edition', $edition, $region, $cost*$hours_month);
+		next if ($edition eq 'standard'); # No commitments
+		foreach my $term ('1y', '3y') {
+			my $cost_term = &find_gcp_bigquery_cost("$mapping.$term", $region, 1);
+			&add_gcp_bigquery_type_cost("month_$term", 'edition', $edition, $region, $cost_term*$hours_month) if (defined $cost_term);
+		}
+	}
+}
+# Active and long-term logical and physical storage per GiB and month
+# https://cloud.google.com/bigquery/pricing#storage
+foreach my $storage (sort keys %{ $gcp->{'bigquery'}->{'storage'} }) {
+	print "Storage: $storage\n";
+	foreach my $region (@bigquery_regions) {
+		my $mapping = "bigquery.storage.$storage";
+		my $cost = &find_gcp_bigquery_cost($mapping, $region, 1); # per GiB and month
+		unless (defined $cost) {
+			warn "WARNING: '$mapping' not found in region '$region'!\n";
+			next;
+		}
+		&add_gcp_bigquery_type_cost('month', 'storage', $storage, $region, $cost);
+	}
+}
+# Streaming inserts per GiB
+# https://cloud.google.com/bigquery/pricing#data_ingestion_pricing
+foreach my $region (@bigquery_regions) {
+	my $mapping = 'bigquery.streaming';
+	my $cost = &find_gcp_bigquery_cost($mapping, $region, 1); # per GiB
+	unless (defined $cost) {
+		warn "WARNING: '$mapping' not found in region '$region'!\n";
+		next;
+	}
+	&add_gcp_bigquery_cost('month', 'streaming', $region, $cost);
+}
+
+
 ###############################################################################
 # NETWORK
 ###############################################################################
@@ -1805,6 +2617,43 @@ sub add_gcp_compute_egress_internet_australia_add_details {
 	$gcp->{'compute'}->{'network'}->{'traffic'}->{'egress'}->{'internet'}->{'australia'}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'description'} = $sku_description;
 }
 
+# &add_gcp_compute_lb_cost($what, $lb, $resource, $region, $cost)
+sub add_gcp_compute_lb_cost {
+	my ($what, $lb, $resource, $region, $cost) = @_;
+	$gcp->{'compute'}->{'network'}->{'lb'}->{$lb}->{$resource}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_compute_lb_details($lb, $resource, $region, $mapping, $sku_id, $value, $nanos, $units, $unit_description, $sku_description)
+sub add_gcp_compute_lb_details {
+	my ($lb, $resource, $region, $mapping, $sku_id, $value, $nanos, $units, $unit_description, $sku_description) = @_;
+	$gcp->{'compute'}->{'network'}->{'lb'}->{$lb}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'sku'}   = $sku_id;
+	$gcp->{'compute'}->{'network'}->{'lb'}->{$lb}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'value'} = $value;
+	$gcp->{'compute'}->{'network'}->{'lb'}->{$lb}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'nanos'} = $nanos;
+	$gcp->{'compute'}->{'network'}->{'lb'}->{$lb}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'units'} = $units;
+	$gcp->{'compute'}->{'network'}->{'lb'}->{$lb}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'unit'} = $unit_description;
+	$gcp->{'compute'}->{'network'}->{'lb'}->{$lb}->{$resource}->{'cost'}->{$region}->{'mapping'}->{$mapping}->{'description'} = $sku_description;
+}
+
+# &add_gcp_compute_inter_zone_cost($what, $region, $cost)
+sub add_gcp_compute_inter_zone_cost {
+	my ($what, $region, $cost) = @_;
+	$gcp->{'compute'}->{'network'}->{'traffic'}->{'egress'}->{'inter-zone'}->{'cost'}->{$region}->{$what} = $cost;
+}
+# &add_gcp_compute_inter_region_cost($what, $source, $destination, $cost)
+# Cost per continent pair. The highest price of all regions of the continents is used.
+sub add_gcp_compute_inter_region_cost {
+	my ($what, $source, $destination, $cost) = @_;
+	my $current = $gcp->{'compute'}->{'network'}->{'traffic'}->{'egress'}->{'inter-region'}->{'cost'}->{$source}->{$destination}->{$what} || 0;
+	$gcp->{'compute'}->{'network'}->{'traffic'}->{'egress'}->{'inter-region'}->{'cost'}->{$source}->{$destination}->{$what} = $cost if ($cost > $current);
+}
+
+# Continent of the locations in the SKU descriptions
+my %continents;
+foreach my $region (keys %{ $gcp->{'region'} }) {
+	my $location  = $gcp->{'region'}->{$region}->{'location'}  || '';
+	my $continent = $gcp->{'region'}->{$region}->{'continent'} || '';
+	$continents{$location} = $continent if ($location && $continent);
+}
+
 &print_header("Network");
 foreach my $region (@regions) {
 	print "Network in region '$region'\n";
@@ -1937,6 +2786,86 @@ foreach my $region (@regions) {
 	}
 	$sth->finish;
 
+	# Cloud Load Balancing
+	#  https://cloud.google.com/vpc/network-pricing#lb
+	# Forwarding rules (minimum service charge includes the first 5 rules) and processed data
+	my %lb_mappings = (
+		'rule'            => 'gce.network.lb.rule',
+		'rule-additional' => 'gce.network.lb.rule.additional',
+		'data'            => 'gce.network.lb.data',
+	);
+	foreach my $resource (sort keys %lb_mappings) {
+		$mapping = $lb_mappings{$resource};
+		print "MAPPING: '$mapping' in region '$region'\n";
+		$sth->execute($mapping, '%'."$region".'%'); # Search SKU(s)
+		while ($sth->fetch) {
+			if (&check_region($region, $regions)) {
+				&mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+				my $cost = &calc_cost($value, $units, $nanos);
+				if ($resource eq 'data') {
+					&add_gcp_compute_lb_cost('month', 'external', $resource, $region, $cost); # per GiB
+				} else {
+					&add_gcp_compute_lb_cost('hour', 'external', $resource, $region, $cost);
+					&add_gcp_compute_lb_cost('month', 'external', $resource, $region, $cost*$hours_month);
+				}
+				&add_gcp_compute_lb_details(
+					'external',
+					$resource,
+					$region,
+					$mapping,
+					$sku_id,
+					$value,
+					$nanos,
+					$units,
+					$unit_description,
+					$sku_description
+				) if ($export_details);
+			}
+		}
+		$sth->finish;
+	}
+
+	# Inter-zone egress
+	#  https://cloud.google.com/vpc/network-pricing#vpc-pricing
+	# VM-to-VM data transfer between zones in the same region
+	$mapping = 'gce.network.inter.zone.egress';
+	print "MAPPING: '$mapping' in region '$region'\n";
+	my $inter_zone_found = 0;
+	foreach my $inter_zone_region ('%'."$region".'%', 'global') {
+		next if ($inter_zone_found);
+		$sth->execute($mapping, $inter_zone_region); # Search SKU(s)
+		while ($sth->fetch) {
+			next if ($inter_zone_found);
+			&mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+			$inter_zone_found = 1;
+			my $cost = &calc_cost($value, $units, $nanos);
+			&add_gcp_compute_inter_zone_cost('month', $region, $cost); # per GiB
+		}
+		$sth->finish;
+	}
+
+	# Inter-region egress
+	# VM-to-VM data transfer between regions, stored as continent pair (source and destination)
+	$mapping = 'gce.network.inter.region.egress';
+	print "MAPPING: '$mapping' in region '$region'\n";
+	my $source_continent = $gcp->{'region'}->{$region}->{'continent'} || '';
+	$sth->execute($mapping, '%'."$region".'%'); # Search SKU(s)
+	while ($sth->fetch) {
+		if ($source_continent && &check_region($region, $regions)) {
+			# Network Inter Region Data Transfer Out from Netherlands to Belgium
+			my ($destination) = $sku_description =~ m/ to (.+)$/;
+			my $destination_continent = $continents{$destination || ''} || '';
+			unless ($destination_continent) {
+				warn "WARNING: Continent of destination '$sku_description' not found!\n";
+				next;
+			}
+			&mapping_found($mapping, $region, $regions, $value, $nanos, $units, $unit_description, $sku_id, $sku_description);
+			my $cost = &calc_cost($value, $units, $nanos);
+			&add_gcp_compute_inter_region_cost('month', $source_continent, $destination_continent, $cost); # per GiB
+		}
+	}
+	$sth->finish;
+
 	# Internet egress rates
 	#  https://cloud.google.com/vpc/network-pricing#vpc-pricing
 	# Network (Egress) Worldwide Destinations (excluding China & Australia, but including Hong Kong)
@@ -1963,6 +2892,7 @@ foreach my $region (@regions) {
 			&add_gcp_compute_egress_internet_add_cost('0-1', $region, $cost_0_1);
 			&add_gcp_compute_egress_internet_add_cost('1-10', $region, $cost_1_10);
 			&add_gcp_compute_egress_internet_add_cost('10n', $region, $cost_10n);
+			$gcp->{'compute'}->{'network'}->{'traffic'}->{'egress'}->{'internet'}->{'tiers'}->{$region} = &calc_tiers($value, $start_amount, $units, $nanos);
 			&add_gcp_compute_egress_internet_add_details(
 				$region,
 				$mapping,
@@ -1997,6 +2927,7 @@ foreach my $region (@regions) {
 			&add_gcp_compute_egress_internet_china_add_cost('0-1', $region, $cost_0_1);
 			&add_gcp_compute_egress_internet_china_add_cost('1-10', $region, $cost_1_10);
 			&add_gcp_compute_egress_internet_china_add_cost('10n', $region, $cost_10n);
+			$gcp->{'compute'}->{'network'}->{'traffic'}->{'egress'}->{'internet'}->{'china'}->{'tiers'}->{$region} = &calc_tiers($value, $start_amount, $units, $nanos);
 			&add_gcp_compute_egress_internet_china_add_details(
 				$region,
 				$mapping,
@@ -2035,6 +2966,7 @@ foreach my $region (@regions) {
 			&add_gcp_compute_egress_internet_australia_add_cost('0-1', $region, $cost_0_1);
 			&add_gcp_compute_egress_internet_australia_add_cost('1-10', $region, $cost_1_10);
 			&add_gcp_compute_egress_internet_australia_add_cost('10n', $region, $cost_10n);
+			$gcp->{'compute'}->{'network'}->{'traffic'}->{'egress'}->{'internet'}->{'australia'}->{'tiers'}->{$region} = &calc_tiers($value, $start_amount, $units, $nanos);
 			&add_gcp_compute_egress_internet_australia_add_details(
 				$region,
 				$mapping,
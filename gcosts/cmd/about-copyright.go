@@ -16,7 +16,6 @@ limitations under the License.
 package cmd
 
 import (
-	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"os"
@@ -26,7 +25,7 @@ var copyrightCmd = &cobra.Command{
 	Use:   "copyright",
 	Short: "copyright information",
 	Run: func(cmd *cobra.Command, args []string) {
-		copyright := pricing.Yml(inputPricing).About.Copyright
+		copyright := loadPricing().About.Copyright
 		if len(copyright) > 0 {
 			pterm.DefaultBox.Println(copyright)
 		} else {
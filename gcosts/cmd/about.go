@@ -16,7 +16,6 @@ limitations under the License.
 package cmd
 
 import (
-	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"os"
@@ -26,7 +25,7 @@ var aboutCmd = &cobra.Command{
 	Use:   "about",
 	Short: "pricing.yml informations",
 	Run: func(cmd *cobra.Command, args []string) {
-		generated := pricing.Yml(inputPricing).About.Generated
+		generated := loadPricing().About.Generated
 		if len(generated) > 0 {
 			pterm.Info.Printf("Last price update: %s\n", generated)
 		} else {
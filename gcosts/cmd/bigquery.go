@@ -0,0 +1,70 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var bigqueryCmd = &cobra.Command{
+	Use:   "bigquery",
+	Short: "BigQuery analysis, editions and storage",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		td := pterm.TableData{
+			{"Resource", "Price", "Unit", "Free per month"},
+		}
+		if cost, err := pricing.CostBigqueryAnalysis(pricingYml, inputRegion); err == nil {
+			td = append(td, []string{"On-demand analysis", fmt.Sprintf("$%.4f", cost.Month), "TiB scanned", fmt.Sprintf("%.0f TiB", pricingYml.Bigquery.Analysis.Free)})
+		}
+		for _, storageType := range append(pricing.ReturnBigqueryStorageTypes(pricing.BigqueryStorageLogical), pricing.ReturnBigqueryStorageTypes(pricing.BigqueryStoragePhysical)...) {
+			if cost, err := pricing.CostBigqueryStorage(pricingYml, storageType, inputRegion); err == nil {
+				td = append(td, []string{storageType + " storage", fmt.Sprintf("$%.4f", cost.Month), "GiB per month", fmt.Sprintf("%.0f GiB", pricingYml.Bigquery.Storage[storageType].Free)})
+			}
+		}
+		if cost, err := pricing.CostBigqueryStreaming(pricingYml, inputRegion); err == nil {
+			td = append(td, []string{"Streaming inserts", fmt.Sprintf("$%.4f", cost.Month), "GiB", ""})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+		td = pterm.TableData{
+			{"Edition", "Slot-hour", "Slot per month", "Slot per month 1Y", "Slot per month 3Y"},
+		}
+		for _, edition := range []string{pricing.BigqueryEditionStandard, pricing.BigqueryEditionEnterprise, pricing.BigqueryEditionEnterprisePlus} {
+			cost, err := pricing.CostBigqueryEdition(pricingYml, edition, inputRegion)
+			if err != nil {
+				continue
+			}
+			td = append(td, []string{
+				edition,
+				fmt.Sprintf("$%.4f", cost.Hour),
+				fmt.Sprintf("$%.2f", cost.Month),
+				fmt.Sprintf("$%.2f", cost.Month1Y),
+				fmt.Sprintf("$%.2f", cost.Month3Y),
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bigqueryCmd)
+	bigqueryCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud region or multi-region (required)")
+	_ = bigqueryCmd.MarkPersistentFlagRequired("region")
+}
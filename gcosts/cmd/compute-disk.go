@@ -26,13 +26,28 @@ var diskCmd = &cobra.Command{
 	Aliases: []string{"storage"},
 	Short:   "Google Compute Engine storage disks",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
+		pricingYml := loadPricing()
 		if len(inputDiskType) > 0 && len(inputRegion) > 0 {
-			cost := pricing.CostComputeDisk(pricingYml, inputDiskType, inputRegion)
-			month := pricing.Month(cost)
+			cost, err := pricing.CostComputeDisk(pricingYml, inputDiskType, inputRegion)
+			exitOnError(err)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
 			pterm.Info.Printf("Price per GiB per month: $%.2f\n", month)
+			disk, err := pricing.CheckComputeDisk(pricingYml, inputDiskType)
+			exitOnError(err)
+			if disk.Partition > 0 {
+				pterm.Info.Printf("Price per partition (%.0f GiB) per month: $%.2f\n", disk.Partition, month*disk.Partition)
+			}
+			if cost, err := pricing.CostComputeDiskIops(pricingYml, inputDiskType, inputRegion); err == nil {
+				pterm.Info.Printf("Price per provisioned IOPS per month: $%.4f (%.0f to %.0f IOPS, %.0f included)\n", cost.Month, disk.Iops.Min, disk.Iops.Max, disk.Iops.Included)
+			}
+			if cost, err := pricing.CostComputeDiskThroughput(pricingYml, inputDiskType, inputRegion); err == nil {
+				pterm.Info.Printf("Price per provisioned MiB/s throughput per month: $%.4f (%.0f to %.0f MiB/s, %.0f included)\n", cost.Month, disk.Throughput.Min, disk.Throughput.Max, disk.Throughput.Included)
+			}
 		} else if len(inputDiskType) > 0 {
-			pricing.CheckComputeDisk(pricingYml, inputDiskType)
+			_, err := pricing.CheckComputeDisk(pricingYml, inputDiskType)
+			exitOnError(err)
+			pterm.Success.Printf("Google Compute Engine storage disk type '%s' found.\n", inputDiskType)
 		} else {
 			var td pterm.TableData
 			td = append(td, []string{"Disk Type"})
@@ -0,0 +1,71 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var gpuCmd = &cobra.Command{
+	Use:   "gpu",
+	Short: "Google Compute Engine GPUs (accelerators)",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		if len(inputGpuType) > 0 && len(inputRegion) > 0 {
+			cost, err := pricing.CostComputeGpu(pricingYml, inputGpuType, inputRegion)
+			exitOnError(err)
+			hour, err := pricing.Hour(cost)
+			exitOnError(err)
+			pterm.Info.Printf("Price per GPU per hour:         $%.4f\n", hour)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
+			pterm.Info.Printf("Price per GPU per month:        $%.2f\n", month)
+			month1Y := returnMonth1Y(cost)
+			pterm.Info.Printf("1Y CUD price per GPU per month: $%.2f\n", month1Y)
+			month3Y := returnMonth3Y(cost)
+			pterm.Info.Printf("3Y CUD price per GPU per month: $%.2f\n", month3Y)
+			monthSpot := returnMonthSpot(cost)
+			pterm.Info.Printf("Spot price per GPU per month:   $%.2f\n", monthSpot)
+		} else if len(inputGpuType) > 0 {
+			gpu, err := pricing.CheckComputeGpu(pricingYml, inputGpuType)
+			exitOnError(err)
+			pterm.Success.Printf("Google Compute Engine GPU type '%s' found.\n", inputGpuType)
+			if len(gpu.Regions) > 0 {
+				regions := append([]string{}, gpu.Regions...)
+				sort.Strings(regions)
+				pterm.Info.Printf("Available in regions: %s\n", strings.Join(regions, ", "))
+			}
+		} else {
+			var td pterm.TableData
+			td = append(td, []string{"GPU Type"})
+			for key := range pricingYml.Compute.Gpu {
+				td = append(td, []string{key})
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+		}
+	},
+}
+
+func init() {
+	computeCmd.AddCommand(gpuCmd)
+	gpuCmd.PersistentFlags().StringVarP(&inputGpuType, "type", "t", "", "Google Compute Engine GPU type")
+	gpuCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud region")
+}
@@ -25,19 +25,26 @@ var instanceCmd = &cobra.Command{
 	Use:   "instance",
 	Short: "Google Compute Engine instances",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
+		pricingYml := loadPricing()
 		if len(inputMachineType) > 0 && len(inputRegion) > 0 {
-			cost := pricing.CostComputeInstance(pricingYml, inputMachineType, inputRegion)
-			month := pricing.Month(cost)
+			cost, err := pricing.CostComputeInstance(pricingYml, inputMachineType, inputRegion)
+			exitOnError(err)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
 			pterm.Info.Printf("Price per month:        $%.2f\n", month)
-			month1Y := pricing.Month1Y(cost)
+			if sud, ok := pricing.SustainedUseDiscount(inputMachineType, pricing.HoursMonth); ok {
+				pterm.Info.Printf("Sustained use discount: %.2f (included in price per month)\n", sud)
+			}
+			month1Y := returnMonth1Y(cost)
 			pterm.Info.Printf("1Y CUD price per month: $%.2f\n", month1Y)
-			month3Y := pricing.Month3Y(cost)
+			month3Y := returnMonth3Y(cost)
 			pterm.Info.Printf("3Y CUD price per month: $%.2f\n", month3Y)
-			monthSpot := pricing.MonthSpot(cost)
+			monthSpot := returnMonthSpot(cost)
 			pterm.Info.Printf("Spot price per month:   $%.2f\n", monthSpot)
 		} else if len(inputMachineType) > 0 {
-			pricing.CheckComputeInstance(pricingYml, inputMachineType)
+			_, err := pricing.CheckComputeInstance(pricingYml, inputMachineType)
+			exitOnError(err)
+			pterm.Success.Printf("Google Compute Engine machine type '%s' found.\n", inputMachineType)
 		} else {
 			var td pterm.TableData
 			td = append(td, []string{"Machine Type"})
@@ -25,14 +25,16 @@ var licenseCmd = &cobra.Command{
 	Use:   "license",
 	Short: "Google Compute Engine licenses",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
+		pricingYml := loadPricing()
 		if len(inputMachineType) > 0 && len(inputOperatingSystem) > 0 {
-			cost := pricing.CostComputeLicense(pricingYml, inputMachineType, inputOperatingSystem)
-			month := pricing.Month(cost)
+			cost, err := pricing.CostComputeLicense(pricingYml, inputMachineType, inputOperatingSystem)
+			exitOnError(err)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
 			pterm.Info.Printf("Price per license per month: $%.2f\n", month)
-			month1Y := pricing.Month1Y(cost)
+			month1Y := returnMonth1Y(cost)
 			pterm.Info.Printf("1Y CUD price per license per month: $%.2f\n", month1Y)
-			month3Y := pricing.Month3Y(cost)
+			month3Y := returnMonth3Y(cost)
 			pterm.Info.Printf("3Y CUD price per license per month: $%.2f\n", month3Y)
 		} else {
 			var td pterm.TableData
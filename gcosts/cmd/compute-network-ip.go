@@ -25,10 +25,16 @@ var computeNetworkIpCmd = &cobra.Command{
 	Use:   "ip",
 	Short: "Google Compute Engine external public IP",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
-		monthVm := pricing.Month(pricing.CostComputeNetworkIpVm(pricingYml, inputRegion))
+		pricingYml := loadPricing()
+		costVm, err := pricing.CostComputeNetworkIpVm(pricingYml, inputRegion)
+		exitOnError(err)
+		monthVm, err := pricing.Month(costVm)
+		exitOnError(err)
 		pterm.Info.Printf("Price per used IP per month:   $%.2f\n", monthVm)
-		monthUnused := pricing.Month(pricing.CostComputeNetworkIpUnused(pricingYml, inputRegion))
+		costUnused, err := pricing.CostComputeNetworkIpUnused(pricingYml, inputRegion)
+		exitOnError(err)
+		monthUnused, err := pricing.Month(costUnused)
+		exitOnError(err)
 		pterm.Info.Printf("Price per unused IP per month: $%.2f\n", monthUnused)
 	},
 }
@@ -0,0 +1,60 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var computeNetworkLbCmd = &cobra.Command{
+	Use:   "lb",
+	Short: "GCE network load balancer (Cloud Load Balancing)",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		if len(inputRegion) > 0 {
+			cost, err := pricing.CostComputeNetworkLbRule(pricingYml, inputLbType, inputRegion)
+			exitOnError(err)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
+			pterm.Info.Printf("Price for the first %d forwarding rules (minimum) per month: $%.2f\n", pricing.LoadBalancerRulesIncluded, month)
+			cost, err = pricing.CostComputeNetworkLbRuleAdditional(pricingYml, inputLbType, inputRegion)
+			exitOnError(err)
+			month, err = pricing.Month(cost)
+			exitOnError(err)
+			pterm.Info.Printf("Price per additional forwarding rule per month:        $%.2f\n", month)
+			cost, err = pricing.CostComputeNetworkLbData(pricingYml, inputLbType, inputRegion)
+			exitOnError(err)
+			month, err = pricing.Month(cost)
+			exitOnError(err)
+			pterm.Info.Printf("Price per GiB processed data:                          $%.4f\n", month)
+		} else {
+			var td pterm.TableData
+			td = append(td, []string{"Load Balancer Type"})
+			for key := range pricingYml.Compute.Network.Lb {
+				td = append(td, []string{key})
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+		}
+	},
+}
+
+func init() {
+	computeNetworkCmd.AddCommand(computeNetworkLbCmd)
+	computeNetworkLbCmd.PersistentFlags().StringVarP(&inputLbType, "type", "t", "external", "Load balancer type")
+	computeNetworkLbCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud region")
+}
@@ -25,9 +25,11 @@ var computeNetworkNatDataCmd = &cobra.Command{
 	Use:   "data",
 	Short: "GCE network NAT ingress and egress data",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
-		cost := pricing.CostComputeNetworkNatData(pricingYml, inputRegion)
-		month := pricing.Month(cost)
+		pricingYml := loadPricing()
+		cost, err := pricing.CostComputeNetworkNatData(pricingYml, inputRegion)
+		exitOnError(err)
+		month, err := pricing.Month(cost)
+		exitOnError(err)
 		pterm.Info.Printf("Price ingress and egress data per GiB per month: $%.2f\n", month)
 	},
 }
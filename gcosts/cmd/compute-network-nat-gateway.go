@@ -25,9 +25,11 @@ var computeNetworkNatGatewayCmd = &cobra.Command{
 	Use:   "gateway",
 	Short: "GCE network NAT gateway",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
-		cost := pricing.CostComputeNetworkNatGateway(pricingYml, inputRegion)
-		month := pricing.Month(cost)
+		pricingYml := loadPricing()
+		cost, err := pricing.CostComputeNetworkNatGateway(pricingYml, inputRegion)
+		exitOnError(err)
+		month, err := pricing.Month(cost)
+		exitOnError(err)
 		pterm.Info.Printf("Price per NAT gateway per month: $%.2f\n", month)
 	},
 }
@@ -17,7 +17,6 @@ package cmd
 
 import (
 	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
@@ -26,21 +25,10 @@ var computeNetworkTrafficEgressAustraliaCmd = &cobra.Command{
 	Aliases: []string{"au"},
 	Short:   "Internet egress traffic with Australia destinations",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
-		var cost pricing.Cost
-		var month float32
-		// 0-1 TiB
-		cost = pricing.CostComputeNetworkTrafficEgressAustraliaTiB0_1(pricingYml, inputRegion)
-		month = pricing.Month(cost)
-		pterm.Info.Printf("Price per GiB (0-1 TiB) per month:  $%.2f\n", month)
-		// 1-10 TiB
-		cost = pricing.CostComputeNetworkTrafficEgressAustraliaTiB1_10(pricingYml, inputRegion)
-		month = pricing.Month(cost)
-		pterm.Info.Printf("Price per GiB (1-10 TiB) per month: $%.2f\n", month)
-		// 10n TiB
-		cost = pricing.CostComputeNetworkTrafficEgressAustraliaTiB10n(pricingYml, inputRegion)
-		month = pricing.Month(cost)
-		pterm.Info.Printf("Price per GiB (10n TiB) per month:  $%.2f\n", month)
+		pricingYml := loadPricing()
+		tiers, err := pricing.CostComputeNetworkTrafficEgressAustraliaTiers(pricingYml, inputRegion)
+		exitOnError(err)
+		printTiers(tiers, "GiB")
 	},
 }
 
@@ -0,0 +1,62 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var computeNetworkTrafficInterRegionCmd = &cobra.Command{
+	Use:   "inter-region",
+	Short: "Google Cloud traffic between regions",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		if len(inputDestinationRegion) > 0 {
+			cost, err := pricing.CostComputeNetworkTrafficInterRegion(pricingYml, inputRegion, inputDestinationRegion)
+			exitOnError(err)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
+			pterm.Info.Printf("Price per GiB inter-region traffic from '%s' to '%s': $%.4f\n", inputRegion, inputDestinationRegion, month)
+		} else {
+			continent, err := pricing.ReturnContinent(pricingYml, inputRegion)
+			exitOnError(err)
+			destinations := pricingYml.Compute.Network.Traffic.Egress.InterRegion.Cost[continent]
+			var keys []string
+			for key := range destinations {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			var td pterm.TableData
+			td = append(td, []string{"Destination Continent", "Price per GiB"})
+			for _, key := range keys {
+				td = append(td, []string{key, fmt.Sprintf("$%.4f", destinations[key].Month)})
+			}
+			_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+		}
+	},
+}
+
+func init() {
+	computeNetworkTrafficCmd.AddCommand(computeNetworkTrafficInterRegionCmd)
+	computeNetworkTrafficInterRegionCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud source region (required)")
+	computeNetworkTrafficInterRegionCmd.PersistentFlags().StringVarP(&inputDestinationRegion, "destination", "d", "", "Google Cloud destination region")
+	_ = computeNetworkTrafficInterRegionCmd.MarkPersistentFlagRequired("region")
+}
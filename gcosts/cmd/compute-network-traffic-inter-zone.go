@@ -0,0 +1,41 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var computeNetworkTrafficInterZoneCmd = &cobra.Command{
+	Use:   "inter-zone",
+	Short: "Google Cloud traffic between zones in the same region",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		cost, err := pricing.CostComputeNetworkTrafficInterZone(pricingYml, inputRegion)
+		exitOnError(err)
+		month, err := pricing.Month(cost)
+		exitOnError(err)
+		pterm.Info.Printf("Price per GiB inter-zone traffic: $%.4f\n", month)
+	},
+}
+
+func init() {
+	computeNetworkTrafficCmd.AddCommand(computeNetworkTrafficInterZoneCmd)
+	computeNetworkTrafficInterZoneCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud region (required)")
+	_ = computeNetworkTrafficInterZoneCmd.MarkPersistentFlagRequired("region")
+}
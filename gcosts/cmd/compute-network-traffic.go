@@ -21,7 +21,7 @@ import (
 
 var computeNetworkTrafficCmd = &cobra.Command{
 	Use:   "traffic",
-	Short: "Google Cloud internet traffic",
+	Short: "Google Cloud internet, inter-zone and inter-region traffic",
 }
 
 func init() {
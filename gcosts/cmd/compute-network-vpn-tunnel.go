@@ -16,7 +16,6 @@ limitations under the License.
 package cmd
 
 import (
-	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"os"
@@ -26,7 +25,7 @@ var computeNetworkVpnTunnelCmd = &cobra.Command{
 	Use:   "tunnel",
 	Short: "GCE network VPN tunnel",
 	Run: func(cmd *cobra.Command, args []string) {
-		regionCost, ok := pricing.Yml(inputPricing).Compute.Network.Vpn.Tunnel.Cost[inputRegion]
+		regionCost, ok := loadPricing().Compute.Network.Vpn.Tunnel.Cost[inputRegion]
 		if ok {
 			pterm.Success.Printf("GCE network VPN tunnel in region '%s' found.\n", inputRegion)
 			month := regionCost.Month
@@ -16,6 +16,8 @@ limitations under the License.
 package cmd
 
 import (
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
@@ -28,3 +30,33 @@ var computeCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(computeCmd)
 }
+
+// returnMonth1Y returns the 1Y CUD price per month or the normal monthly price with a warning
+func returnMonth1Y(cost pricing.Cost) float32 {
+	if !(cost.Month1Y > 0) {
+		pterm.Warning.Println("1Y CUD price per month not found! Apply normal monthly price.")
+	}
+	month1Y, err := pricing.Month1Y(cost)
+	exitOnError(err)
+	return month1Y
+}
+
+// returnMonth3Y returns the 3Y CUD price per month or the normal monthly price with a warning
+func returnMonth3Y(cost pricing.Cost) float32 {
+	if !(cost.Month3Y > 0) {
+		pterm.Warning.Println("3Y CUD price per month not found! Apply normal monthly price.")
+	}
+	month3Y, err := pricing.Month3Y(cost)
+	exitOnError(err)
+	return month3Y
+}
+
+// returnMonthSpot returns the Spot price per month or the normal monthly price with a warning
+func returnMonthSpot(cost pricing.Cost) float32 {
+	if !(cost.MonthSpot > 0) {
+		pterm.Warning.Println("Spot price per month not found! Apply normal monthly price.")
+	}
+	monthSpot, err := pricing.MonthSpot(cost)
+	exitOnError(err)
+	return monthSpot
+}
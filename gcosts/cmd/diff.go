@@ -0,0 +1,239 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff OLD NEW",
+	Short: "Cost difference between two usage directories, CSV exports or git revisions",
+	Long: `Cost difference between two usage directories, CSV exports or git revisions.
+
+OLD and NEW can be
+* a directory with YAML usage files,
+* a CSV file exported with 'gcosts calc' or
+* a git revision and directory with YAML usage files (REVISION:DIRECTORY, e.g. main:usage).
+
+Line items are matched by project, usage file, resource and name.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnError(checkDiffOutput())
+		exitOnError(pricing.CheckTierScope(inputTierScope))
+		pricingYml := loadPricing()
+		oldLineItems := diffLineItems(pricingYml, args[0])
+		newLineItems := diffLineItems(pricingYml, args[1])
+		diff := pricing.DiffLineItems(oldLineItems, newLineItems)
+
+		if inputOutput == outputTable {
+			printDiff(diff)
+		} else {
+			exitOnFileError(writeOutput(func(writer io.Writer) error {
+				if inputOutput == outputJson {
+					return encodeJson(writer, diff)
+				}
+				return writeDiffMarkdown(writer, diff)
+			}))
+			if !reportToStdout() {
+				pterm.Success.Printf("Cost difference saved to file '%s'.\n", inputOutputFile)
+			}
+		}
+	},
+}
+
+// checkDiffOutput returns an error if the output format or output file is not supported
+func checkDiffOutput() error {
+	switch inputOutput {
+	case outputTable:
+		if len(inputOutputFile) > 0 {
+			return fmt.Errorf("output file requires output format '%s' or '%s'", outputJson, outputMarkdown)
+		}
+	case outputJson, outputMarkdown:
+	default:
+		return fmt.Errorf("output format '%s' not supported, use '%s', '%s' or '%s'", inputOutput, outputTable, outputJson, outputMarkdown)
+	}
+	return nil
+}
+
+// diffLineItems returns the line items of a usage directory, CSV export or git revision
+func diffLineItems(pricingYml pricing.StructPricing, input string) []pricing.LineItem {
+	info, err := os.Stat(input)
+	if err != nil {
+		revision, dir, ok := strings.Cut(input, ":")
+		if !ok {
+			pterm.Error.Println(err)
+			os.Exit(9)
+		}
+		tmp, err := gitUsageDir(revision, dir)
+		if err != nil {
+			pterm.Error.Println(err)
+			os.Exit(9)
+		}
+		defer func() {
+			_ = os.RemoveAll(tmp)
+		}()
+		pterm.Info.Printf("Git revision '%s' with usage directory '%s'\n", revision, dir)
+		return calcDiffDir(pricingYml, tmp)
+	}
+	if info.IsDir() {
+		return calcDiffDir(pricingYml, input)
+	}
+	pterm.Info.Printf("CSV file with costs: '%s'\n", input)
+	lineItems, err := pricing.ReadCsv(input)
+	exitOnFileError(err)
+	return lineItems
+}
+
+// calcDiffDir calculates the costs of the usage files in the directory
+func calcDiffDir(pricingYml pricing.StructPricing, dir string) []pricing.LineItem {
+	estimate := pricing.NewEstimate(pricingYml)
+	estimate.Logger = ptermLogger{}
+	calcDir(estimate, dir)
+	exitOnError(estimate.AggregateTiers(inputTierScope))
+	estimate.ApplyCommitments()
+	return estimate.LineItems
+}
+
+// gitUsageDir copies the YAML usage files of the directory in the git revision to a temporary directory.
+// The directory is relative to the root of the git repository.
+func gitUsageDir(revision string, dir string) (string, error) {
+	out, err := exec.Command("git", "ls-tree", "-z", "--name-only", revision+":"+dir).Output()
+	if err != nil {
+		return "", fmt.Errorf("directory '%s' in git revision '%s' not found: %w", dir, revision, err)
+	}
+	tmp, err := os.MkdirTemp("", "gcosts-diff-")
+	if err != nil {
+		return "", err
+	}
+	// File names are separated by NUL and may contain spaces
+	for _, name := range strings.Split(string(out), "\x00") {
+		if !strings.HasSuffix(name, ".yml") {
+			continue
+		}
+		content, err := exec.Command("git", "show", revision+":"+path.Join(dir, name)).Output()
+		if err == nil {
+			err = os.WriteFile(filepath.Join(tmp, name), content, 0644)
+		}
+		if err != nil {
+			_ = os.RemoveAll(tmp)
+			return "", fmt.Errorf("usage file '%s' in git revision '%s' not read: %w", name, revision, err)
+		}
+	}
+	return tmp, nil
+}
+
+// printDiff renders the cost difference as tables
+func printDiff(diff pricing.Diff) {
+	pterm.DefaultSection.WithLevel(2).Println("💰 Cost difference")
+	if len(diff.Items) > 0 {
+		td := pterm.TableData{
+			{"Status", "Name", "Res.", "Type/Class", "Old", "New", "Delta"},
+		}
+		for _, item := range diff.Items {
+			td = append(td, []string{
+				item.Status,
+				fmt.Sprintf("%.30s", item.Name),
+				fmt.Sprintf("%.10s", item.Resource),
+				fmt.Sprintf("%.25s", diffType(item)),
+				fmt.Sprintf("%.2f", item.OldCost),
+				fmt.Sprintf("%.2f", item.NewCost),
+				fmt.Sprintf("%+.2f", item.Delta),
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+
+		td = pterm.TableData{
+			{"Resource", "Old", "New", "Delta"},
+		}
+		for _, resource := range diff.Resources {
+			td = append(td, []string{
+				resource.Resource,
+				fmt.Sprintf("%.2f", resource.OldCost),
+				fmt.Sprintf("%.2f", resource.NewCost),
+				fmt.Sprintf("%+.2f", resource.Delta),
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+	} else {
+		pterm.Info.Println("No line item was added, removed or changed.")
+	}
+	pterm.DefaultBasicText.Println("Total cost: " +
+		fmt.Sprintf("%.2f → %.2f ", diff.OldTotal, diff.NewTotal) +
+		pterm.LightMagenta(fmt.Sprintf("(%+.2f)", diff.Delta)))
+}
+
+// writeDiffMarkdown writes the cost difference as Markdown, e.g. for pull request comments
+func writeDiffMarkdown(writer io.Writer, diff pricing.Diff) error {
+	var md strings.Builder
+	md.WriteString("### 💸 Monthly cost difference\n\n")
+	md.WriteString("| Old | New | Delta |\n|----:|----:|------:|\n")
+	fmt.Fprintf(&md, "| %.2f | %.2f | **%+.2f** |\n", diff.OldTotal, diff.NewTotal, diff.Delta)
+	if len(diff.Resources) > 0 {
+		md.WriteString("\n#### Resources\n\n")
+		md.WriteString("| Resource | Old | New | Delta |\n|----------|----:|----:|------:|\n")
+		for _, resource := range diff.Resources {
+			fmt.Fprintf(&md, "| %s | %.2f | %.2f | %+.2f |\n", markdownCell(resource.Resource), resource.OldCost, resource.NewCost, resource.Delta)
+		}
+	}
+	if len(diff.Items) > 0 {
+		md.WriteString("\n#### Line items\n\n")
+		md.WriteString("| Status | Project | File | Resource | Name | Type/Class | Old | New | Delta |\n")
+		md.WriteString("|--------|---------|------|----------|------|------------|----:|----:|------:|\n")
+		for _, item := range diff.Items {
+			fmt.Fprintf(&md, "| %s | %s | %s | %s | %s | %s | %.2f | %.2f | %+.2f |\n",
+				item.Status, markdownCell(item.Project), markdownCell(item.File), markdownCell(item.Resource),
+				markdownCell(item.Name), markdownCell(diffType(item)),
+				item.OldCost, item.NewCost, item.Delta)
+		}
+	}
+	_, err := io.WriteString(writer, md.String())
+	return err
+}
+
+// markdownCell escapes the text of a Markdown table cell
+func markdownCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(text)
+}
+
+// diffType returns the type of the line item or "old → new" if the type changed
+func diffType(item pricing.DiffItem) string {
+	switch {
+	case item.Status == pricing.DiffAdded:
+		return item.NewType
+	case item.Status == pricing.DiffRemoved || item.OldType == item.NewType:
+		return item.OldType
+	}
+	return item.OldType + " → " + item.NewType
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.PersistentFlags().StringVarP(&inputOutput, "output", "o", outputTable, "Output format (table, json or markdown)")
+	diffCmd.PersistentFlags().StringVar(&inputTierScope, "tier-scope", pricing.TierScopeTotal, "Aggregate tiered usage (e.g. internet egress traffic) per total, project or billing-account")
+	diffCmd.PersistentFlags().StringVar(&inputOutputFile, "output-file", "", "Write the output to this file instead of stdout (json or markdown)")
+}
@@ -0,0 +1,185 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+)
+
+func TestMarkdownCell(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"e2-small", "e2-small"},
+		{"a|b", "a\\|b"},
+		{"line\nbreak", "line break"},
+		{"|x|\n", "\\|x\\| "},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			if got := markdownCell(test.text); got != test.want {
+				t.Errorf("markdownCell(%q) = %q, want %q", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiffType(t *testing.T) {
+	tests := []struct {
+		name string
+		item pricing.DiffItem
+		want string
+	}{
+		{"added", pricing.DiffItem{Status: pricing.DiffAdded, NewType: "e2-small"}, "e2-small"},
+		{"removed", pricing.DiffItem{Status: pricing.DiffRemoved, OldType: "e2-small"}, "e2-small"},
+		{"same type", pricing.DiffItem{Status: pricing.DiffChanged, OldType: "e2-small", NewType: "e2-small"}, "e2-small"},
+		{"changed type", pricing.DiffItem{Status: pricing.DiffChanged, OldType: "e2-small", NewType: "e2-medium"}, "e2-small → e2-medium"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := diffType(test.item); got != test.want {
+				t.Errorf("diffType() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWriteDiffMarkdown(t *testing.T) {
+	diff := pricing.Diff{
+		OldTotal:  10,
+		NewTotal:  15,
+		Delta:     5,
+		Resources: []pricing.ResourceDelta{{Resource: "vm", OldCost: 10, NewCost: 15, Delta: 5}},
+		Items: []pricing.DiffItem{
+			{Status: pricing.DiffChanged, Project: "p", File: "a|b.yml", Resource: "vm", Name: "web\nserver", OldType: "e2-small", NewType: "e2-medium", OldCost: 10, NewCost: 15, Delta: 5},
+		},
+	}
+	var md strings.Builder
+	if err := writeDiffMarkdown(&md, diff); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"| 10.00 | 15.00 | **+5.00** |",
+		"| vm | 10.00 | 15.00 | +5.00 |",
+		"| changed | p | a\\|b.yml | vm | web server | e2-small → e2-medium | 10.00 | 15.00 | +5.00 |",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown does not contain %q:\n%s", want, md.String())
+		}
+	}
+}
+
+func TestGitUsageDir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	files := map[string]string{
+		"usage/web server.yml": "project: web\n",
+		"usage/db.yml":         "project: db\n",
+		"usage/README.md":      "# Usage\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "--quiet")
+	git("add", ".")
+	git("commit", "--quiet", "-m", "usage")
+	t.Chdir(repo)
+
+	tests := []struct {
+		name      string
+		revision  string
+		dir       string
+		wantFiles []string
+		wantErr   bool
+	}{
+		{
+			name:      "YAML files with spaces",
+			revision:  "HEAD",
+			dir:       "usage",
+			wantFiles: []string{"db.yml", "web server.yml"},
+		},
+		{
+			name:     "unknown directory",
+			revision: "HEAD",
+			dir:      "unknown",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown revision",
+			revision: "unknown",
+			dir:      "usage",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmp, err := gitUsageDir(test.revision, test.dir)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				_ = os.RemoveAll(tmp)
+			}()
+			entries, err := os.ReadDir(tmp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			if !slices.Equal(names, test.wantFiles) {
+				t.Errorf("files = %v, want %v", names, test.wantFiles)
+			}
+			content, err := os.ReadFile(filepath.Join(tmp, "web server.yml"))
+			if err != nil || string(content) != files["usage/web server.yml"] {
+				t.Errorf("content = %q (%v), want %q", content, err, files["usage/web server.yml"])
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/usage"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var inputOutputDir string
+
+// gcloudCmd represents the gcloud command
+var gcloudCmd = &cobra.Command{
+	Use:   "gcloud JSON-FILE...",
+	Short: "Convert gcloud exports to YAML usage files",
+	Long: `Convert the resources of gcloud JSON exports to YAML usage files (one per project).
+
+Supported exports:
+  gcloud compute instances list --format=json > instances.json
+  gcloud compute disks list --format=json > disks.json
+  gcloud compute addresses list --format=json > addresses.json
+  gcloud compute routers list --format=json > routers.json
+  gcloud compute vpn-tunnels list --format=json > vpn-tunnels.json
+  gcloud asset list --project=PROJECT --content-type=resource --format=json > assets.json
+
+Without disks export the disk type of the instance disks is unknown and 'balanced' is used.
+Usage that is not part of the export (e.g. data in buckets) must be added to the YAML usage file.`,
+	Args: cobra.MinimumNArgs(1),
+	// Write YAML usage file to stdout or output file
+	Annotations: map[string]string{"output": outputYaml},
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, file := range args {
+			pterm.Info.Printf("gcloud JSON file: '%s'\n", file)
+		}
+		projects, skipped, err := usage.Gcloud(args)
+		exitOnFileError(err)
+		for _, resource := range skipped {
+			pterm.Warning.Printf("Resource '%s' skipped: %s\n", resource.Address, resource.Reason)
+		}
+		names := slices.Sorted(maps.Keys(projects))
+
+		if len(inputOutputDir) == 0 {
+			if len(names) != 1 {
+				exitOnError(fmt.Errorf("resources of %d projects found, use --output-dir to save one YAML usage file per project", len(names)))
+			}
+			exitOnFileError(writeOutput(func(writer io.Writer) error {
+				return writeUsage(writer, projects[names[0]])
+			}))
+			if !reportToStdout() {
+				pterm.Success.Printf("YAML usage file saved to '%s'.\n", inputOutputFile)
+			}
+			return
+		}
+		for _, name := range names {
+			file := name + ".yml"
+			if len(name) == 0 {
+				file = "gcloud.yml"
+			}
+			path := filepath.Join(inputOutputDir, file)
+			output, err := os.Create(path)
+			exitOnFileError(err)
+			err = writeUsage(output, projects[name])
+			if closeErr := output.Close(); err == nil {
+				err = closeErr
+			}
+			exitOnFileError(err)
+			pterm.Success.Printf("YAML usage file saved to '%s'.\n", path)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gcloudCmd)
+	gcloudCmd.PersistentFlags().StringVar(&inputOutputFile, "output-file", "", "Write the YAML usage file to this file instead of stdout")
+	gcloudCmd.PersistentFlags().StringVar(&inputOutputDir, "output-dir", "", "Save one YAML usage file per project in this directory")
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var gkeAutopilotCmd = &cobra.Command{
+	Use:   "autopilot",
+	Short: "Google Kubernetes Engine Autopilot pod requests",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		cpu, err := pricing.CostGkeAutopilotCpu(pricingYml, inputRegion)
+		exitOnError(err)
+		ram, err := pricing.CostGkeAutopilotRam(pricingYml, inputRegion)
+		exitOnError(err)
+		storage, err := pricing.CostGkeAutopilotStorage(pricingYml, inputRegion)
+		exitOnError(err)
+		td := pterm.TableData{
+			{"Request", "Month", "Month Spot", "Month 1Y CUD", "Month 3Y CUD"},
+		}
+		for _, request := range []struct {
+			name string
+			cost pricing.Cost
+		}{
+			{"vCPU", cpu},
+			{"GiB memory", ram},
+			{"GiB ephemeral storage", storage},
+		} {
+			month, err := pricing.Month(request.cost)
+			exitOnError(err)
+			monthSpot, _ := pricing.MonthSpot(request.cost)
+			month1Y, _ := pricing.Month1Y(request.cost)
+			month3Y, _ := pricing.Month3Y(request.cost)
+			td = append(td, []string{
+				request.name,
+				fmt.Sprintf("$%.4f", month),
+				fmt.Sprintf("$%.4f", monthSpot),
+				fmt.Sprintf("$%.4f", month1Y),
+				fmt.Sprintf("$%.4f", month3Y),
+			})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+	},
+}
+
+func init() {
+	gkeCmd.AddCommand(gkeAutopilotCmd)
+	gkeAutopilotCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud region (required)")
+	_ = gkeAutopilotCmd.MarkPersistentFlagRequired("region")
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+)
+
+// ptermLogger renders the diagnostics of the pricing calculation with pterm
+// and stores them in the collector if it is set
+type ptermLogger struct {
+	collector *pricing.Collector
+}
+
+func (l ptermLogger) Log(diagnostic pricing.Diagnostic) {
+	if l.collector != nil {
+		l.collector.Log(diagnostic)
+	}
+	switch diagnostic.Level {
+	case pricing.LevelSuccess:
+		pterm.Success.Println(diagnostic.Message)
+	case pricing.LevelWarning:
+		pterm.Warning.Println(diagnostic.Message)
+	default:
+		pterm.Info.Println(diagnostic.Message)
+	}
+}
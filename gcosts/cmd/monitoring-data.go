@@ -17,7 +17,6 @@ package cmd
 
 import (
 	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
@@ -25,21 +24,10 @@ var monitoringDataCmd = &cobra.Command{
 	Use:   "data",
 	Short: "Google Cloud Monitoring data informations",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
-		var cost pricing.Cost
-		var month float32
-		// 0-1 TiB
-		cost = pricing.CostMonitoringDataMiB0_100000(pricingYml, inputRegion)
-		month = pricing.Month(cost)
-		pterm.Info.Printf("Price per MiB (0-100,000 MiB) per month:       $%.2f\n", month)
-		// 1-10 TiB
-		cost = pricing.CostMonitoringDataMiB0_100000_250000(pricingYml, inputRegion)
-		month = pricing.Month(cost)
-		pterm.Info.Printf("Price per MiB (100,000-250,000 MiB) per month: $%.2f\n", month)
-		// 10n TiB
-		cost = pricing.CostMonitoringDataMiB0_250000n(pricingYml, inputRegion)
-		month = pricing.Month(cost)
-		pterm.Info.Printf("Price per MiB (250,000n MiB) per month:        $%.2f\n", month)
+		pricingYml := loadPricing()
+		tiers, err := pricing.CostMonitoringDataTiers(pricingYml, inputRegion)
+		exitOnError(err)
+		printTiers(tiers, "MiB")
 	},
 }
 
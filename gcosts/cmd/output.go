@@ -0,0 +1,137 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the calc and diff command
+const (
+	outputTable    = "table"
+	outputJson     = "json"
+	outputYaml     = "yaml"
+	outputCsv      = "csv"
+	outputMarkdown = "markdown"
+)
+
+var inputOutput string
+var inputOutputFile string
+var forceExport bool
+var noExport bool
+
+// checkOutput returns an error if the output format or output file is not supported
+func checkOutput() error {
+	switch inputOutput {
+	case outputTable:
+		if len(inputOutputFile) > 0 {
+			return fmt.Errorf("output file requires output format '%s', '%s' or '%s'", outputJson, outputYaml, outputCsv)
+		}
+	case outputJson, outputYaml, outputCsv:
+	default:
+		return fmt.Errorf("output format '%s' not supported, use '%s', '%s', '%s' or '%s'", inputOutput, outputTable, outputJson, outputYaml, outputCsv)
+	}
+	if reportToStdout() && exportToStdout() {
+		return fmt.Errorf("output and CSV export can not both be written to stdout")
+	}
+	return nil
+}
+
+// reportToStdout returns true if the output format is machine-readable and no output file is set
+func reportToStdout() bool {
+	switch inputOutput {
+	case outputJson, outputYaml, outputCsv, outputMarkdown:
+		return len(inputOutputFile) == 0 || inputOutputFile == "-"
+	}
+	return false
+}
+
+// exportToStdout returns true if the CSV export file is stdout ("-")
+func exportToStdout() bool {
+	return !noExport && inputExportCsv == "-"
+}
+
+// outputToStdout returns true if machine-readable output is written to stdout.
+// All other messages are then written to stderr.
+func outputToStdout() bool {
+	return reportToStdout() || exportToStdout()
+}
+
+// isTerminal returns true if the file is a terminal.
+// Stdin is not a terminal if gcosts runs in a pipeline or CI.
+func isTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
+
+// writeOutput writes the output with the encode function to stdout or the output file
+func writeOutput(encode func(writer io.Writer) error) error {
+	if reportToStdout() {
+		return encode(os.Stdout)
+	}
+	file, err := os.Create(inputOutputFile)
+	if err != nil {
+		return err
+	}
+	err = encode(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// writeReport writes the report in the output format to stdout or the output file
+func writeReport(report pricing.Report) error {
+	return writeOutput(func(writer io.Writer) error {
+		switch inputOutput {
+		case outputJson:
+			return encodeJson(writer, report)
+		case outputYaml:
+			encoder := yaml.NewEncoder(writer)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(report); err != nil {
+				return err
+			}
+			return encoder.Close()
+		default:
+			return pricing.WriteCsv(writer, report.LineItems)
+		}
+	})
+}
+
+func encodeJson(writer io.Writer, v any) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printTiers prints the price per unit of all tiers
+func printTiers(tiers []pricing.Tier, unit string) {
+	for i, tier := range tiers {
+		tierRange := fmt.Sprintf("%g+ %s", tier.Start, unit)
+		if i+1 < len(tiers) {
+			tierRange = fmt.Sprintf("%g-%g %s", tier.Start, tiers[i+1].Start, unit)
+		}
+		pterm.Info.Printf("Price per %s (%s) per month: $%.2f\n", unit, tierRange, tier.Month)
+	}
+}
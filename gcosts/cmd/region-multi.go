@@ -19,21 +19,17 @@ import (
 	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
-	"os"
 )
 
 var regionMultiCmd = &cobra.Command{
 	Use:   "multi",
 	Short: "Google Cloud multi-regions",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
+		pricingYml := loadPricing()
 		if len(inputRegion) > 0 {
-			if pricing.CheckRegion(pricingYml, inputRegion) {
-				pterm.Info.Printf("Google Cloud region: %s\n", inputRegion)
-			} else {
-				pterm.Error.Printf("Google Cloud region '%s' not found!\n", inputRegion)
-				os.Exit(1)
-			}
+			exitOnError(pricing.CheckRegion(pricingYml, inputRegion))
+			pterm.Success.Printf("Google Cloud region '%s' found.\n", inputRegion)
+			pterm.Info.Printf("Google Cloud region: %s\n", inputRegion)
 		} else {
 			var td pterm.TableData
 			td = append(td, []string{"Region", "Description"})
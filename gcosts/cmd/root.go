@@ -16,13 +16,16 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
@@ -45,6 +48,14 @@ to quickly see a cost breakdown and compare different options upfront.
 More help: <https://github.com/Cyclenerd/google-cloud-pricing-cost-calculator>`,
 	// PersistentPreRun: children of this command will inherit and execute.
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Commands with fixed output format
+		if format, ok := cmd.Annotations["output"]; ok {
+			inputOutput = format
+		}
+		// Keep stdout clean for machine-readable output
+		if outputToStdout() {
+			pterm.SetDefaultOutput(os.Stderr)
+		}
 		pterm.DefaultHeader.WithFullWidth().Println("💸 gcosts - Google Cloud Platform Pricing and Cost Calculator")
 
 		// Handle pricing file download if requested
@@ -65,18 +76,23 @@ var defaultDir string       // current working directory
 var defaultPricing string   // pricing.yml in current working directory
 var defaultExportCsv string // costs.csv in current working directory
 
-var defaultRegion string = "us-central1"
-var defaultProject string = "default-project-id"
-var defaultDiscount float32 = 0.0000
-
 var inputPricing string
 var inputUsageDir string
 var inputExportCsv string
+var inputBudget string
+var inputBaseline string
+var inputTierScope string
 var inputRegion string
 var inputStorageClass string
 var inputDiskType string
 var inputMachineType string
 var inputOperatingSystem string
+var inputGpuType string
+var inputLbType string
+var inputDestinationRegion string
+var inputSqlDatabase string
+var inputSqlEdition string
+var inputSqlTier string
 
 // Download-related variables
 var downloadPricing bool
@@ -154,6 +170,30 @@ func ensurePricingFile() string {
 	return cachedPath
 }
 
+// loadPricing reads the YAML file with the pricing information.
+// Exits with exit code 9 if the file can not be read and 8 if it can not be parsed.
+func loadPricing() pricing.StructPricing {
+	pterm.Info.Printf("YAML file with GCP pricing informations: '%s'\n", inputPricing)
+	pricingYml, err := pricing.Yml(inputPricing)
+	if err != nil {
+		pterm.Error.Println(err)
+		var pathError *fs.PathError
+		if errors.As(err, &pathError) {
+			os.Exit(9)
+		}
+		os.Exit(8)
+	}
+	return pricingYml
+}
+
+// exitOnError prints the error and exits with exit code 1 (resource, region or price not found)
+func exitOnError(err error) {
+	if err != nil {
+		pterm.Error.Println(err)
+		os.Exit(1)
+	}
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -0,0 +1,77 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var serverlessCmd = &cobra.Command{
+	Use:     "serverless",
+	Aliases: []string{"run", "functions"},
+	Short:   "Cloud Run services and Cloud Run functions",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		td := pterm.TableData{
+			{"Billing", "Resource", "Price", "Unit", "Free per month"},
+		}
+		for _, billing := range []string{pricing.ServerlessBillingRequest, pricing.ServerlessBillingInstance} {
+			prices, err := pricing.CheckServerlessBilling(pricingYml, billing)
+			if err != nil {
+				continue
+			}
+			for _, resource := range []struct {
+				name string
+				unit string
+				free float32
+				cost func(pricing.StructPricing, string, string) (pricing.Cost, error)
+			}{
+				{"vCPU", "vCPU-second", prices.Cpu.Free, pricing.CostServerlessCpu},
+				{"Memory", "GiB-second", prices.Ram.Free, pricing.CostServerlessRam},
+				{"Requests", "1 million requests", prices.Requests.Free, pricing.CostServerlessRequests},
+				{"Idle vCPU", "vCPU-second", prices.IdleCpu.Free, pricing.CostServerlessIdleCpu},
+				{"Idle memory", "GiB-second", prices.IdleRam.Free, pricing.CostServerlessIdleRam},
+			} {
+				cost, err := resource.cost(pricingYml, billing, inputRegion)
+				if err != nil {
+					continue
+				}
+				price := cost.Hour / 3600
+				if resource.name == "Requests" {
+					price = cost.Month
+				}
+				td = append(td, []string{
+					billing + "-based",
+					resource.name,
+					fmt.Sprintf("$%.7f", price),
+					resource.unit,
+					fmt.Sprintf("%.0f", resource.free),
+				})
+			}
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serverlessCmd)
+	serverlessCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud region (required)")
+	_ = serverlessCmd.MarkPersistentFlagRequired("region")
+}
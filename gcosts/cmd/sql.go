@@ -0,0 +1,122 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+var sqlCmd = &cobra.Command{
+	Use:     "sql",
+	Aliases: []string{"cloud-sql"},
+	Short:   "Cloud SQL instances, storage and backups",
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		database, edition := pricing.ReturnSqlDatabase(inputSqlDatabase, inputSqlEdition)
+		exitOnError(pricing.CheckSqlDatabase(database, edition))
+		if len(inputSqlTier) > 0 {
+			machine, err := pricing.CheckSqlTier(pricingYml, database, edition, inputSqlTier)
+			exitOnError(err)
+			cost, err := pricing.CostSqlInstance(pricingYml, database, edition, inputSqlTier, inputRegion)
+			exitOnError(err)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
+			month1Y, _ := pricing.Month1Y(cost)
+			month3Y, _ := pricing.Month3Y(cost)
+			pterm.Info.Printf("Cloud SQL %s %s tier '%s' (%.0f vCPU, %.2f GiB memory)\n", database, edition, inputSqlTier, machine.Cpu, machine.Ram)
+			pterm.Info.Printf("Price per month: $%.2f\n", month)
+			pterm.Info.Printf("Price per month with high availability: $%.2f\n", month*pricing.SqlHighAvailability)
+			pterm.Info.Printf("Price per month with 1Y CUD: $%.2f\n", month1Y)
+			pterm.Info.Printf("Price per month with 3Y CUD: $%.2f\n", month3Y)
+			if database == "sqlserver" && edition != "express" {
+				license, err := pricing.CostSqlLicense(pricingYml, edition, inputRegion)
+				exitOnError(err)
+				licenseMonth, err := pricing.Month(license)
+				exitOnError(err)
+				pterm.Info.Printf("Price SQL Server license per month: $%.2f\n", licenseMonth*machine.Cpu)
+			}
+			return
+		}
+		td := pterm.TableData{
+			{"Edition", "Resource", "Month", "Month 1Y CUD", "Month 3Y CUD"},
+		}
+		for _, edition := range []string{pricing.SqlEditionEnterprise, pricing.SqlEditionEnterprisePlus} {
+			resource, ok := pricingYml.Sql.Edition[edition]
+			if !ok {
+				continue
+			}
+			for _, price := range []struct {
+				name string
+				cost map[string]pricing.Cost
+			}{
+				{"vCPU", resource.Cpu.Cost},
+				{"GiB memory", resource.Ram.Cost},
+			} {
+				cost, ok := price.cost[inputRegion]
+				if !ok {
+					continue
+				}
+				td = append(td, []string{
+					edition,
+					price.name,
+					fmt.Sprintf("$%.4f", cost.Month),
+					fmt.Sprintf("$%.4f", cost.Month1Y),
+					fmt.Sprintf("$%.4f", cost.Month3Y),
+				})
+			}
+		}
+		var tiers []string
+		for tier := range pricingYml.Sql.Tier {
+			tiers = append(tiers, tier)
+		}
+		sort.Strings(tiers)
+		for _, tier := range tiers {
+			if cost, ok := pricingYml.Sql.Tier[tier].Cost[inputRegion]; ok {
+				td = append(td, []string{
+					pricing.SqlEditionEnterprise,
+					tier,
+					fmt.Sprintf("$%.4f", cost.Month),
+					"",
+					"",
+				})
+			}
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+		td = pterm.TableData{
+			{"Storage", "Month per GiB"},
+		}
+		for _, storageType := range []string{"ssd", "hdd", "backup"} {
+			if cost, err := pricing.CostSqlStorage(pricingYml, storageType, inputRegion); err == nil {
+				td = append(td, []string{storageType, fmt.Sprintf("$%.4f", cost.Month)})
+			}
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sqlCmd)
+	sqlCmd.PersistentFlags().StringVarP(&inputRegion, "region", "r", "", "Google Cloud region (required)")
+	sqlCmd.PersistentFlags().StringVarP(&inputSqlTier, "tier", "t", "", "Cloud SQL tier, e.g. db-custom-2-7680")
+	sqlCmd.PersistentFlags().StringVarP(&inputSqlDatabase, "database", "", "", "Database engine: mysql (default), postgres or sqlserver")
+	sqlCmd.PersistentFlags().StringVarP(&inputSqlEdition, "edition", "", "", "Edition: enterprise (default) or enterprise-plus, SQL Server: standard (default), enterprise, web or express")
+	_ = sqlCmd.MarkPersistentFlagRequired("region")
+}
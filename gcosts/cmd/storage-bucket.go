@@ -25,13 +25,32 @@ var bucketCmd = &cobra.Command{
 	Use:   "bucket",
 	Short: "Google Cloud Storage buckets",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
+		pricingYml := loadPricing()
 		if len(inputStorageClass) > 0 && len(inputRegion) > 0 {
-			cost := pricing.CostStorageBucket(pricingYml, inputStorageClass, inputRegion)
-			month := pricing.Month(cost)
+			cost, err := pricing.CostStorageBucket(pricingYml, inputStorageClass, inputRegion)
+			exitOnError(err)
+			month, err := pricing.Month(cost)
+			exitOnError(err)
 			pterm.Info.Printf("Price per GiB per month: $%.2f\n", month)
+			// Optional prices, not every storage class has them
+			if cost, err := pricing.CostStorageOperationsClassA(pricingYml, inputStorageClass, inputRegion); err == nil {
+				pterm.Info.Printf("Price per %.0f Class A operations: $%.4f\n", pricing.StorageOperations, cost.Month)
+			}
+			if cost, err := pricing.CostStorageOperationsClassB(pricingYml, inputStorageClass, inputRegion); err == nil {
+				pterm.Info.Printf("Price per %.0f Class B operations: $%.4f\n", pricing.StorageOperations, cost.Month)
+			}
+			if resource, err := pricing.CheckStorageEarlyDeletion(pricingYml, inputStorageClass); err == nil {
+				if cost, err := pricing.CostStorageEarlyDeletion(pricingYml, inputStorageClass, inputRegion); err == nil {
+					pterm.Info.Printf("Early deletion fee per GiB per month (minimum storage duration %.0f days): $%.4f\n", resource.Days, cost.Month)
+				}
+			}
+			if cost, err := pricing.CostStorageReplication(pricingYml, inputStorageClass, inputRegion); err == nil {
+				pterm.Info.Printf("Replication per GiB: $%.2f\n", cost.Month)
+			}
 		} else if len(inputStorageClass) > 0 {
-			pricing.CheckStorageBucket(pricingYml, inputStorageClass)
+			_, err := pricing.CheckStorageBucket(pricingYml, inputStorageClass)
+			exitOnError(err)
+			pterm.Success.Printf("Google Cloud Storage class '%s' found.\n", inputStorageClass)
 		} else {
 			var td pterm.TableData
 			td = append(td, []string{"Storage Class"})
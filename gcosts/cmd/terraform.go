@@ -0,0 +1,104 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/usage"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var inputTerraformPlan string
+
+// terraformCmd represents the terraform command
+var terraformCmd = &cobra.Command{
+	Use:   "terraform PLAN-JSON",
+	Short: "Convert Terraform plan to YAML usage file",
+	Long: `Convert the resources of a Terraform plan to a YAML usage file.
+
+Create the Terraform plan JSON file with:
+  terraform plan -out=plan.tfplan
+  terraform show -json plan.tfplan > plan.json
+
+Supported resources:
+* google_compute_instance
+* google_compute_disk
+* google_storage_bucket
+* google_compute_router_nat
+* google_compute_vpn_tunnel
+* google_compute_address (as external IP address of the instance)
+
+Usage that is not part of the plan (e.g. data in buckets) must be added to the YAML usage file.`,
+	Args: cobra.ExactArgs(1),
+	// Write YAML usage file to stdout or output file
+	Annotations: map[string]string{"output": outputYaml},
+	Run: func(cmd *cobra.Command, args []string) {
+		usageYml := terraformPlan(args[0])
+		exitOnFileError(writeOutput(func(writer io.Writer) error {
+			return writeUsage(writer, usageYml)
+		}))
+		if !reportToStdout() {
+			pterm.Success.Printf("YAML usage file saved to '%s'.\n", inputOutputFile)
+		}
+	},
+}
+
+// terraformPlan converts the Terraform plan to usage and prints the skipped resources
+func terraformPlan(file string) usage.StructUsage {
+	pterm.Info.Printf("Terraform plan JSON file: '%s'\n", file)
+	usageYml, skipped, err := usage.TerraformPlan(file)
+	exitOnFileError(err)
+	for _, resource := range skipped {
+		pterm.Warning.Printf("Resource '%s' skipped: %s\n", resource.Address, resource.Reason)
+	}
+	return usageYml
+}
+
+// calcTerraformPlan calculates the costs of the resources of the Terraform plan and adds them to the estimate
+func calcTerraformPlan(estimate *pricing.Estimate, file string) {
+	pterm.DefaultSection.WithLevel(2).Printf("📝 Terraform plan %s\n", file)
+	usageYml, skipped, err := usage.TerraformPlan(file)
+	exitOnFileError(err)
+	for _, resource := range skipped {
+		estimate.Logger.Log(pricing.Diagnostic{
+			Level:   pricing.LevelWarning,
+			Message: fmt.Sprintf("Resource '%s' skipped: %s", resource.Address, resource.Reason),
+			File:    filepath.Base(file),
+		})
+	}
+	exitOnError(calcUsage(estimate, filepath.Base(file), usageYml))
+}
+
+// writeUsage writes the usage as YAML usage file
+func writeUsage(writer io.Writer, usageYml usage.StructUsage) error {
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(usageYml); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func init() {
+	rootCmd.AddCommand(terraformCmd)
+	terraformCmd.PersistentFlags().StringVar(&inputOutputFile, "output-file", "", "Write the YAML usage file to this file instead of stdout")
+}
@@ -18,8 +18,11 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
 	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/usage"
@@ -34,132 +37,495 @@ var usageCmd = &cobra.Command{
 	SuggestFor: []string{"usage"},
 	Short:      "Usage files",
 	Run: func(cmd *cobra.Command, args []string) {
-		pricingYml := pricing.Yml(inputPricing)
-
-		pterm.DefaultSection.Printf("📂 Directory %s\n", inputUsageDir)
-		files := usage.ReadDir(inputUsageDir)
-
-		for _, file := range files {
-			filepath := filepath.Join(inputUsageDir, file)
-			pterm.DefaultSection.WithLevel(2).Printf("📝 File %s\n", file)
-			usageYml := usage.Yml(filepath)
-
-			// Overwrite defaults
-			defaultProject = pricing.ReturnProject(defaultProject, usageYml.Project)
-			defaultRegion = pricing.ReturnRegion(pricingYml, defaultRegion, usageYml.Region)
-			defaultDiscount = pricing.ReturnDiscount(defaultDiscount, usageYml.Discount)
-
-			// Store information for cost line item
-			pricing.File = file
-			pricing.Project = defaultProject
-
-			// Calc pricing of resources
-			disks := usageYml.Disks
-			buckets := usageYml.Buckets
-			if len(usageYml.Monitoring) > 0 {
-				pterm.DefaultSection.WithLevel(3).Println("🚦 Monitoring")
-				for _, monitoring := range usageYml.Monitoring {
-					region, discount := pricing.OverwirteDefault(pricingYml, defaultRegion, monitoring.Region, defaultDiscount, monitoring.Discount)
-					pricing.CalcMonitoring(pricingYml, monitoring.Name, monitoring.Data, region, discount)
+		exitOnError(checkOutput())
+		exitOnError(pricing.CheckTierScope(inputTierScope))
+		pricingYml := loadPricing()
+		collector := &pricing.Collector{}
+		estimate := pricing.NewEstimate(pricingYml)
+		estimate.Logger = ptermLogger{collector: collector}
+
+		// Read baseline before the export file is overwritten
+		var baseline []pricing.LineItem
+		if len(inputBaseline) > 0 {
+			pterm.Info.Printf("CSV file with baseline costs: '%s'\n", inputBaseline)
+			var err error
+			baseline, err = pricing.ReadCsv(inputBaseline)
+			exitOnFileError(err)
+		}
+
+		calcDir(estimate, inputUsageDir)
+		if len(inputTerraformPlan) > 0 {
+			calcTerraformPlan(estimate, inputTerraformPlan)
+		}
+		exitOnError(estimate.AggregateTiers(inputTierScope))
+		commitments := estimate.ApplyCommitments()
+		printCommitments(commitments)
+
+		// Check budgets
+		if len(inputBudget) > 0 {
+			pterm.Info.Printf("YAML file with budgets: '%s'\n", inputBudget)
+			budgetYml, err := usage.BudgetYml(inputBudget)
+			exitOnFileError(err)
+			addBudgets(estimate, budgetYml)
+		}
+		budgets := estimate.CheckBudgets(baseline)
+		printBudgets(budgets)
+
+		if inputOutput == outputTable {
+			printCosts(estimate)
+		} else {
+			exitOnFileError(writeReport(estimate.Report(collector.Warnings(), budgets, commitments)))
+			if !reportToStdout() {
+				pterm.Success.Printf("Costs saved to file '%s'.\n", inputOutputFile)
+			}
+		}
+
+		// Export CSV file
+		exportCosts(estimate.LineItems)
+
+		for _, budget := range budgets {
+			if budget.Exceeded {
+				pterm.Error.Println("Budget exceeded!")
+				os.Exit(11)
+			}
+		}
+
+		// Done
+		pterm.DefaultHeader.WithFullWidth().Println("✅ Done - Calculation of costs for used resources completed")
+	},
+}
+
+// calcDir calculates the costs of the resources of all usage files in the directory
+func calcDir(estimate *pricing.Estimate, dir string) {
+	pterm.DefaultSection.Printf("📂 Directory %s\n", dir)
+	files := usage.ReadDir(dir)
+
+	for _, file := range files {
+		filepath := filepath.Join(dir, file)
+		pterm.DefaultSection.WithLevel(2).Printf("📝 File %s\n", file)
+		usageYml := usage.Yml(filepath)
+		exitOnError(calcUsage(estimate, file, usageYml))
+	}
+}
+
+// calcUsage calculates the costs of the resources of one usage file and adds them to the estimate
+func calcUsage(estimate *pricing.Estimate, file string, usageYml usage.StructUsage) error {
+	// Overwrite defaults
+	err := estimate.SetDefaults(usageYml.Project, usageYml.Region, usageYml.Discount)
+	if err != nil {
+		return err
+	}
+
+	// Store information for cost line item
+	estimate.File = file
+	estimate.SetBillingAccount(usageYml.BillingAccount)
+
+	// Budgets of usage file
+	estimate.AddBudget(pricing.Budget{
+		Scope:  pricing.BudgetFile,
+		Name:   file,
+		Amount: usageYml.Budget.File,
+		Growth: usageYml.Budget.Growth,
+	})
+	estimate.AddBudget(pricing.Budget{
+		Scope:  pricing.BudgetProject,
+		Name:   estimate.Project,
+		Amount: usageYml.Budget.Project,
+	})
+
+	// Flexible commitments of the billing account
+	for _, commitment := range usageYml.Commitments {
+		if err := estimate.AddCommitment(commitment.Name, commitment.Term, commitment.Amount); err != nil {
+			return err
+		}
+	}
+
+	// Calc pricing of resources
+	disks := usageYml.Disks
+	buckets := usageYml.Buckets
+	if len(usageYml.Monitoring) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🚦 Monitoring")
+		for _, monitoring := range usageYml.Monitoring {
+			region, discount, err := estimate.OverwriteDefault(monitoring.Region, monitoring.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcMonitoring(monitoring.Name, monitoring.Data, region, discount); err != nil {
+				return err
+			}
+		}
+	}
+	if len(usageYml.VpnTunnels) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🚇 Cloud VPN")
+		for _, vpnTunnel := range usageYml.VpnTunnels {
+			region, discount, err := estimate.OverwriteDefault(vpnTunnel.Region, vpnTunnel.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcComputeNetworkVpnTunnel(vpnTunnel.Name, region, discount); err != nil {
+				return err
+			}
+		}
+	}
+	if len(usageYml.NatGateways) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🔗 Cloud NAT")
+		for _, natGateway := range usageYml.NatGateways {
+			region, discount, err := estimate.OverwriteDefault(natGateway.Region, natGateway.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcComputeNetworkNatGateway(natGateway.Name, natGateway.Data, region, discount); err != nil {
+				return err
+			}
+		}
+	}
+	if len(usageYml.LoadBalancers) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("⚖️  Cloud Load Balancing")
+		for _, loadBalancer := range usageYml.LoadBalancers {
+			region, discount, err := estimate.OverwriteDefault(loadBalancer.Region, loadBalancer.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcComputeNetworkLb(loadBalancer.Name, loadBalancer.Type, loadBalancer.Rules, loadBalancer.Data, region, discount); err != nil {
+				return err
+			}
+		}
+	}
+	if len(usageYml.Traffic) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🕸️  Network")
+		for _, traffic := range usageYml.Traffic {
+			region, discount, err := estimate.OverwriteDefault(traffic.Region, traffic.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcComputeNetworkTrafficEgress(traffic.Name, traffic.World, traffic.China, traffic.Australia, region, discount); err != nil {
+				return err
+			}
+			if traffic.InterZone > 0 {
+				if _, err := estimate.CalcComputeNetworkTrafficInterZone(traffic.Name, traffic.InterZone, region, discount); err != nil {
+					return err
 				}
 			}
-			if len(usageYml.VpnTunnels) > 0 {
-				pterm.DefaultSection.WithLevel(3).Println("🚇 Cloud VPN")
-				for _, vpnTunnel := range usageYml.VpnTunnels {
-					region, discount := pricing.OverwirteDefault(pricingYml, defaultRegion, vpnTunnel.Region, defaultDiscount, vpnTunnel.Discount)
-					pricing.CalcComputeNetworkVpnTunnel(pricingYml, vpnTunnel.Name, region, discount)
+			for _, interRegion := range traffic.InterRegion {
+				if _, err := estimate.CalcComputeNetworkTrafficInterRegion(traffic.Name, interRegion.Data, region, interRegion.Region, discount); err != nil {
+					return err
 				}
 			}
-			if len(usageYml.NatGateways) > 0 {
-				pterm.DefaultSection.WithLevel(3).Println("🔗 Cloud NAT")
-				for _, natGateway := range usageYml.NatGateways {
-					region, discount := pricing.OverwirteDefault(pricingYml, defaultRegion, natGateway.Region, defaultDiscount, natGateway.Discount)
-					pricing.CalcComputeNetworkNatGateway(pricingYml, natGateway.Name, natGateway.Data, region, discount)
+		}
+	}
+	if len(usageYml.GkeClusters) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("☸️  Google Kubernetes Engine")
+		for _, cluster := range usageYml.GkeClusters {
+			pterm.DefaultSection.WithLevel(4).Printf("Cluster '%s'\n", cluster.Name)
+			region, discount, err := estimate.OverwriteDefault(cluster.Region, cluster.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcGkeCluster(cluster.Name, cluster.Mode, cluster.Regional, region, discount); err != nil {
+				return err
+			}
+			if cluster.Mode == pricing.GkeModeAutopilot {
+				if _, err := estimate.CalcGkeAutopilot(cluster.Name, cluster.Cpu, cluster.Memory, cluster.Storage, region, discount, cluster.Commitment, cluster.Spot); err != nil {
+					return err
 				}
+				continue
 			}
-			if len(usageYml.Traffic) > 0 {
-				pterm.DefaultSection.WithLevel(3).Println("🕸️  Network")
-				for _, traffic := range usageYml.Traffic {
-					region, discount := pricing.OverwirteDefault(pricingYml, defaultRegion, traffic.Region, defaultDiscount, traffic.Discount)
-					pricing.CalcComputeNetworkTrafficEgress(pricingYml, traffic.Name, traffic.World, traffic.China, traffic.Australia, region, discount)
+			// Nodes of the node pools are instances with boot disk
+			for _, node := range cluster.Nodes() {
+				region, discount, err := estimate.OverwriteDefault(node.Region, node.Discount)
+				if err != nil {
+					return err
 				}
+				if _, err := estimate.CalcComputeInstance(node.Name, node.Type, region, discount, node.Commitment, node.Spot, false, 0); err != nil {
+					return err
+				}
+				for _, disk := range node.Disks {
+					if _, err := estimate.CalcComputeDisk(disk.Name, disk.Type, disk.Data, region, discount); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if len(usageYml.SqlInstances) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🐬 Cloud SQL")
+		for _, instance := range usageYml.SqlInstances {
+			region, discount, err := estimate.OverwriteDefault(instance.Region, instance.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcSqlInstance(instance.Name, instance.Database, instance.Edition, instance.SqlTier(), instance.HighAvailability, region, discount, instance.Commitment); err != nil {
+				return err
+			}
+			if _, err := estimate.CalcSqlStorage(instance.Name, instance.StorageType, instance.Storage, instance.Backup, instance.HighAvailability, region, discount); err != nil {
+				return err
 			}
-			if len(usageYml.Instances) > 0 {
-				pterm.DefaultSection.WithLevel(3).Println("🖥️  Compute Engine Instances")
-				for _, instance := range usageYml.Instances {
-					region, discount := pricing.OverwirteDefault(pricingYml, defaultRegion, instance.Region, defaultDiscount, instance.Discount)
-					pricing.CalcComputeInstance(pricingYml, instance.Name, instance.Type, region, discount, instance.Commitment, instance.Spot, instance.Terminated)
-					pricing.CalcComputeLicense(pricingYml, instance.Name, instance.Type, instance.Os, discount, instance.Commitment, instance.Terminated)
-					pricing.CalcComputeNetworkIp(pricingYml, instance.Name, instance.ExternalIp, region, discount, instance.Terminated)
-					disks = append(disks, instance.Disks...)
-					buckets = append(buckets, instance.Buckets...)
+		}
+	}
+	if len(usageYml.Serverless) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🏃 Cloud Run")
+		for _, serverless := range usageYml.Serverless {
+			region, discount, err := estimate.OverwriteDefault(serverless.Region, serverless.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcServerless(serverless.Name, serverless.Billing, serverless.Cpu, serverless.Memory, serverless.Requests, serverless.Duration, serverless.Concurrency, serverless.MinInstances, region, discount); err != nil {
+				return err
+			}
+		}
+	}
+	if len(usageYml.Bigquery) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🔍 BigQuery")
+		for _, bigquery := range usageYml.Bigquery {
+			region, discount, err := estimate.OverwriteDefault(bigquery.Region, bigquery.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcBigqueryAnalysis(bigquery.Name, bigquery.Analysis, region, discount); err != nil {
+				return err
+			}
+			if bigquery.BaselineSlots > 0 || bigquery.MaxSlots > 0 {
+				if _, err := estimate.CalcBigquerySlots(bigquery.Name, bigquery.Edition, bigquery.BaselineSlots, bigquery.MaxSlots, bigquery.AutoscaleHours, region, discount, bigquery.Commitment); err != nil {
+					return err
+				}
+			}
+			if _, err := estimate.CalcBigqueryStorage(bigquery.Name, bigquery.StorageBilling, bigquery.Active, bigquery.LongTerm, bigquery.Streaming, region, discount); err != nil {
+				return err
+			}
+		}
+	}
+	if len(usageYml.Instances) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🖥️  Compute Engine Instances")
+		for _, instance := range usageYml.Instances {
+			region, discount, err := estimate.OverwriteDefault(instance.Region, instance.Discount)
+			if err != nil {
+				return err
+			}
+			hours, err := instance.Runtime()
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcComputeInstance(instance.Name, instance.Type, region, discount, instance.Commitment, instance.Spot, instance.Terminated, hours); err != nil {
+				return err
+			}
+			for _, accelerator := range instance.Accelerators {
+				if _, err := estimate.CalcComputeGpu(instance.Name, accelerator.Type, accelerator.Count, instance.Type, region, discount, instance.Commitment, instance.Spot, instance.Terminated, hours); err != nil {
+					return err
+				}
+			}
+			if _, err := estimate.CalcComputeLicense(instance.Name, instance.Type, instance.Os, discount, instance.Commitment, instance.Terminated, hours); err != nil {
+				return err
+			}
+			if _, err := estimate.CalcComputeNetworkIp(instance.Name, instance.ExternalIp, region, discount, instance.Terminated, hours); err != nil {
+				return err
+			}
+			disks = append(disks, instance.Disks...)
+			buckets = append(buckets, instance.Buckets...)
+		}
+	}
+	if len(disks) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("💾 Compute Engine Disks")
+		for _, disk := range disks {
+			region, discount, err := estimate.OverwriteDefault(disk.Region, disk.Discount)
+			if err != nil {
+				return err
+			}
+			if disk.Partitions > 0 {
+				if _, err := estimate.CalcComputeDiskPartitions(disk.Name, disk.Type, disk.Partitions, region, discount); err != nil {
+					return err
+				}
+			} else if _, err := estimate.CalcComputeDisk(disk.Name, disk.Type, disk.Data, region, discount); err != nil {
+				return err
+			}
+			if disk.Iops > 0 || disk.Throughput > 0 {
+				if _, err := estimate.CalcComputeDiskPerformance(disk.Name, disk.Type, disk.Iops, disk.Throughput, region, discount); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if len(buckets) > 0 {
+		pterm.DefaultSection.WithLevel(3).Println("🪣 Cloud Storage")
+		for _, bucket := range buckets {
+			region, discount, err := estimate.OverwriteDefault(bucket.Region, bucket.Discount)
+			if err != nil {
+				return err
+			}
+			if _, err := estimate.CalcStorageBucket(bucket.Name, bucket.Class, bucket.Data, bucket.Retrieval, region, discount); err != nil {
+				return err
+			}
+			if bucket.ClassA > 0 || bucket.ClassB > 0 {
+				if _, err := estimate.CalcStorageBucketOperations(bucket.Name, bucket.Class, bucket.ClassA, bucket.ClassB, region, discount); err != nil {
+					return err
 				}
 			}
-			if len(disks) > 0 {
-				pterm.DefaultSection.WithLevel(3).Println("💾 Compute Engine Disks")
-				for _, disk := range disks {
-					region, discount := pricing.OverwirteDefault(pricingYml, defaultRegion, disk.Region, defaultDiscount, disk.Discount)
-					pricing.CalcComputeDisk(pricingYml, disk.Name, disk.Type, disk.Data, region, discount)
+			if bucket.EarlyDeletion > 0 {
+				if _, err := estimate.CalcStorageBucketEarlyDeletion(bucket.Name, bucket.Class, bucket.EarlyDeletion, bucket.EarlyDeletionDays, region, discount); err != nil {
+					return err
 				}
 			}
-			if len(buckets) > 0 {
-				pterm.DefaultSection.WithLevel(3).Println("🪣 Cloud Storage")
-				for _, bucket := range buckets {
-					region, discount := pricing.OverwirteDefault(pricingYml, defaultRegion, bucket.Region, defaultDiscount, bucket.Discount)
-					pricing.CalcStorageBucket(pricingYml, bucket.Name, bucket.Class, bucket.Data, bucket.Retrieval, region, discount)
+			if bucket.Replication > 0 {
+				if _, err := estimate.CalcStorageBucketReplication(bucket.Name, bucket.Class, bucket.Replication, region, discount); err != nil {
+					return err
 				}
 			}
 		}
+	}
+	return nil
+}
 
-		var td pterm.TableData
-		// PROJECT;REGION;RESOURCE;NAME;COST;TYPE;DATA;CLASS;COMMITMENT;DISCOUNT;FILE
+// addBudgets adds the budgets of the global budget configuration to the estimate
+func addBudgets(estimate *pricing.Estimate, budgetYml usage.StructBudget) {
+	estimate.AddBudget(pricing.Budget{
+		Scope:  pricing.BudgetTotal,
+		Amount: budgetYml.Total.Budget,
+		Growth: budgetYml.Total.Growth,
+	})
+	for _, file := range slices.Sorted(maps.Keys(budgetYml.Files)) {
+		estimate.AddBudget(pricing.Budget{
+			Scope:  pricing.BudgetFile,
+			Name:   file,
+			Amount: budgetYml.Files[file].Budget,
+			Growth: budgetYml.Files[file].Growth,
+		})
+	}
+	for _, project := range slices.Sorted(maps.Keys(budgetYml.Projects)) {
+		estimate.AddBudget(pricing.Budget{
+			Scope:  pricing.BudgetProject,
+			Name:   project,
+			Amount: budgetYml.Projects[project].Budget,
+			Growth: budgetYml.Projects[project].Growth,
+		})
+	}
+}
+
+// printBudgets prints the result of the budget checks
+func printBudgets(budgets []pricing.BudgetCheck) {
+	if len(budgets) == 0 {
+		return
+	}
+	pterm.DefaultSection.WithLevel(2).Println("🎯 Budgets")
+	for _, budget := range budgets {
+		if budget.Exceeded {
+			pterm.Error.Println(budget.Message)
+		} else {
+			pterm.Success.Println(budget.Message)
+		}
+	}
+}
+
+// printCommitments prints the coverage and utilization of the flexible commitments
+func printCommitments(commitments []pricing.CommitmentUsage) {
+	if len(commitments) == 0 {
+		return
+	}
+	pterm.DefaultSection.WithLevel(2).Println("🤝 Commitments")
+	for _, commitment := range commitments {
+		pterm.Info.Printf("Flexible commitment '%s' (%d year): coverage %.2f%%, utilization %.2f%%, costs $%.2f\n",
+			commitment.Name, commitment.Term, commitment.Coverage, commitment.Utilization, commitment.Cost+commitment.Unused)
+		if commitment.Unused > 0 {
+			pterm.Warning.Printf("Unused commitment '%s': $%.2f per month\n", commitment.Name, commitment.Unused)
+		}
+	}
+}
+
+// printCosts renders the line items of the estimate as table with the total cost
+func printCosts(estimate *pricing.Estimate) {
+	var td pterm.TableData
+	// PROJECT;REGION;RESOURCE;NAME;COST;TYPE;DATA;CLASS;COMMITMENT;DISCOUNT;FILE
+	td = append(td, []string{
+		"Name",
+		"Res.",
+		"Type/Class",
+		"Cost",
+		"CUD",
+		"SUD",
+		"Disc.",
+	})
+	for _, lineItem := range estimate.LineItems {
 		td = append(td, []string{
-			"Name",
-			"Res.",
-			"Type/Class",
-			"Cost",
-			"CUD",
-			"Disc.",
+			fmt.Sprintf("%.30s", lineItem.Name),
+			fmt.Sprintf("%.10s", lineItem.Resource),
+			fmt.Sprintf("%.25s", lineItem.Type),
+			fmt.Sprintf("%.2f", lineItem.Cost),
+			fmt.Sprintf("%v", lineItem.Commitment),
+			returnSud(lineItem.Sud),
+			fmt.Sprintf("%.2f", lineItem.Discount),
 		})
-		var totalCosts float32
-		for _, lineItem := range pricing.LineItems {
-			td = append(td, []string{
-				fmt.Sprintf("%.30s", lineItem.Name),
-				fmt.Sprintf("%.10s", lineItem.Resource),
-				fmt.Sprintf("%.25s", lineItem.Type),
-				fmt.Sprintf("%.2f", lineItem.Cost),
-				fmt.Sprintf("%v", lineItem.Commitment),
-				fmt.Sprintf("%.2f", lineItem.Discount),
-			})
-			totalCosts = totalCosts + lineItem.Cost
-		}
-
-		pterm.DefaultSection.WithLevel(2).Println("💰 Costs")
-		_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
-		pterm.DefaultBasicText.Println("Total cost: " + pterm.LightMagenta(fmt.Sprintf("%.2f", totalCosts)))
+	}
 
-		// Export CSV file
-		if _, err := os.Stat(inputExportCsv); errors.Is(err, os.ErrNotExist) {
-			// file does not exist
-			pricing.ExportCsv(pricing.LineItems, inputExportCsv)
+	pterm.DefaultSection.WithLevel(2).Println("💰 Costs")
+	_ = pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(td).Render()
+	pterm.DefaultBasicText.Println("Total cost: " + pterm.LightMagenta(fmt.Sprintf("%.2f", estimate.Total())))
+}
+
+// returnSud returns the sustained use discount for the table or an empty string if not eligible
+func returnSud(sud float32) string {
+	if sud > 0 {
+		return fmt.Sprintf("%.2f", sud)
+	}
+	return ""
+}
+
+// exportCosts exports the line items to the CSV export file or stdout.
+// An existing export file is only overwritten with --force or after confirmation.
+// Without terminal (e.g. in CI) there is no confirmation and it exits with exit code 10.
+func exportCosts(lineItems []pricing.LineItem) {
+	if noExport {
+		pterm.Info.Println("Export of CSV file skipped.")
+		return
+	}
+	if exportToStdout() {
+		exitOnFileError(pricing.WriteCsv(os.Stdout, lineItems))
+		return
+	}
+	if _, err := os.Stat(inputExportCsv); errors.Is(err, os.ErrNotExist) || forceExport {
+		// file does not exist or should be overwritten
+		exportCsv(lineItems, inputExportCsv)
+	} else if !isTerminal(os.Stdin) {
+		pterm.Error.Printf("Export file '%s' exists! Use --force to overwrite it or --no-export to skip the export.\n", inputExportCsv)
+		os.Exit(10)
+	} else {
+		// file exists
+		pterm.Warning.Printf("Export file '%s' exists! Should it be overwritten?\n", inputExportCsv)
+		result, _ := pterm.DefaultInteractiveConfirm.Show()
+		if result {
+			exportCsv(lineItems, inputExportCsv)
 		} else {
-			// file exists
-			pterm.Warning.Printf("Export file '%s' exists! Should it be overwritten?\n", inputExportCsv)
-			result, _ := pterm.DefaultInteractiveConfirm.Show()
-			if result {
-				pricing.ExportCsv(pricing.LineItems, inputExportCsv)
-			} else {
-				pterm.Warning.Println("Export file not saved!")
-			}
+			pterm.Warning.Println("Export file not saved!")
 		}
+	}
+}
 
-		// Done
-		pterm.DefaultHeader.WithFullWidth().Println("✅ Done - Calculation of costs for used resources completed")
-	},
+// exportCsv exports the line items to the CSV file
+func exportCsv(lineItems []pricing.LineItem, inputExportCsv string) {
+	exitOnFileError(pricing.ExportCsv(lineItems, inputExportCsv))
+}
+
+// exitOnFileError prints the error and exits.
+// Exits with exit code 9 if the file can not be read or created and 8 if it can not be parsed or written.
+func exitOnFileError(err error) {
+	if err != nil {
+		pterm.Error.Println(err)
+		var pathError *fs.PathError
+		if errors.As(err, &pathError) {
+			os.Exit(9)
+		}
+		os.Exit(8)
+	}
 }
 
 func init() {
 	rootCmd.AddCommand(usageCmd)
 	usageCmd.PersistentFlags().StringVarP(&inputUsageDir, "dir", "d", defaultDir, "Directory with YAML usage files")
-	usageCmd.PersistentFlags().StringVarP(&inputExportCsv, "csv", "e", defaultExportCsv, "Export CSV file with costs for resources")
+	usageCmd.PersistentFlags().StringVarP(&inputExportCsv, "csv", "e", defaultExportCsv, "Export CSV file with costs for resources (- for stdout)")
+	usageCmd.PersistentFlags().StringVar(&inputExportCsv, "export-to", defaultExportCsv, "Same as --csv")
+	usageCmd.PersistentFlags().BoolVar(&forceExport, "force", false, "Overwrite an existing CSV export file without confirmation")
+	usageCmd.PersistentFlags().BoolVar(&noExport, "no-export", false, "Do not export a CSV file")
+	usageCmd.PersistentFlags().StringVar(&inputTerraformPlan, "terraform-plan", "", "Terraform plan JSON file (terraform show -json) with additional resources")
+	usageCmd.PersistentFlags().StringVar(&inputTierScope, "tier-scope", pricing.TierScopeTotal, "Aggregate tiered usage (e.g. internet egress traffic) per total, project or billing-account")
+	usageCmd.PersistentFlags().StringVar(&inputBudget, "budget", "", "YAML file with budgets for the total costs, usage files and projects")
+	usageCmd.PersistentFlags().StringVar(&inputBaseline, "baseline", "", "CSV file with costs (e.g. previous export) to check the growth of the costs")
+	usageCmd.PersistentFlags().StringVarP(&inputOutput, "output", "o", outputTable, "Output format (table, json, yaml or csv)")
+	usageCmd.PersistentFlags().StringVar(&inputOutputFile, "output-file", "", "Write the output to this file instead of stdout (json, yaml or csv)")
 }
@@ -0,0 +1,52 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/usage"
+)
+
+func TestAddBudgets(t *testing.T) {
+	estimate := &pricing.Estimate{}
+	// Budgets of the usage files
+	estimate.AddBudget(pricing.Budget{Scope: pricing.BudgetFile, Name: "web.yml", Amount: 100, Growth: 10})
+	estimate.AddBudget(pricing.Budget{Scope: pricing.BudgetFile, Name: "db.yml", Amount: 50})
+	estimate.AddBudget(pricing.Budget{Scope: pricing.BudgetProject, Name: "my-project", Amount: 500})
+
+	addBudgets(estimate, usage.StructBudget{
+		Total:    usage.Limit{Budget: 1000},
+		Files:    map[string]usage.Limit{"web.yml": {Budget: 200}},
+		Projects: map[string]usage.Limit{"other-project": {Growth: 5}, "empty-project": {}},
+	})
+	want := []pricing.Budget{
+		{Scope: pricing.BudgetFile, Name: "web.yml", Amount: 200},
+		{Scope: pricing.BudgetFile, Name: "db.yml", Amount: 50},
+		{Scope: pricing.BudgetProject, Name: "my-project", Amount: 500},
+		{Scope: pricing.BudgetTotal, Amount: 1000},
+		{Scope: pricing.BudgetProject, Name: "other-project", Growth: 5},
+	}
+	if len(estimate.Budgets) != len(want) {
+		t.Fatalf("budgets = %+v, want %+v", estimate.Budgets, want)
+	}
+	for i := range want {
+		if estimate.Budgets[i] != want[i] {
+			t.Errorf("budget %d = %+v, want %+v", i, estimate.Budgets[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/usage"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate [YAML-FILE...]",
+	Short: "Validate usage files",
+	Long: `Validate YAML usage files without calculating the costs.
+
+Reports all problems at once with file, line and column:
+* unknown fields (e.g. typos like 'comitment')
+* wrong types and values out of range (e.g. commitment other than 0, 1 or 3, negative data)
+* regions, machine types, disk types, storage classes and licenses not found in the pricing information
+
+Without files all usage files in the directory are validated.
+Exits with exit code 12 if there are problems.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		pricingYml := loadPricing()
+		files := args
+		if len(files) == 0 {
+			for _, file := range usage.ReadDir(inputUsageDir) {
+				files = append(files, filepath.Join(inputUsageDir, file))
+			}
+		}
+
+		// Defaults of a usage file are inherited by the following usage files (like calc)
+		region := pricing.NewEstimate(pricingYml).Region
+		invalid := 0
+		for _, file := range files {
+			usageYml, problems, err := usage.Validate(file, pricingYml, region)
+			if err != nil {
+				var pathError *fs.PathError
+				if errors.As(err, &pathError) {
+					pterm.Error.Println(err)
+					os.Exit(9)
+				}
+				pterm.Error.Printf("%s: %v\n", file, err)
+				invalid++
+				continue
+			}
+			for _, problem := range problems {
+				pterm.Error.Println(problem)
+			}
+			if len(problems) > 0 {
+				invalid++
+			} else {
+				pterm.Success.Printf("YAML usage file '%s' is valid.\n", file)
+			}
+			if pricing.CheckRegion(pricingYml, usageYml.Region) == nil {
+				region = usageYml.Region
+			}
+		}
+
+		if invalid > 0 {
+			pterm.Error.Printf("%d of %d YAML usage files are invalid!\n", invalid, len(files))
+			os.Exit(12)
+		}
+		pterm.DefaultHeader.WithFullWidth().Println("✅ Done - All usage files are valid")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.PersistentFlags().StringVarP(&inputUsageDir, "dir", "d", defaultDir, "Directory with YAML usage files")
+}
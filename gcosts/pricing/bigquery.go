@@ -0,0 +1,271 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+// BigQuery
+
+// Editions of the BigQuery capacity pricing
+const (
+	BigqueryEditionStandard       = "standard"
+	BigqueryEditionEnterprise     = "enterprise"
+	BigqueryEditionEnterprisePlus = "enterprise-plus"
+)
+
+// Storage billing models of BigQuery datasets
+const (
+	BigqueryStorageLogical  = "logical"
+	BigqueryStoragePhysical = "physical"
+)
+
+// BigquerySlots is the increment of the baseline and autoscaling slots
+const BigquerySlots = 50
+
+// CheckBigqueryEdition returns an error if the edition is unknown
+func CheckBigqueryEdition(pricingYml StructPricing, inputEdition string) (BigqueryPrice, error) {
+	edition, ok := pricingYml.Bigquery.Edition[inputEdition]
+	if !ok {
+		return BigqueryPrice{}, &ResourceError{Resource: "BigQuery edition", Name: inputEdition}
+	}
+	return edition, nil
+}
+
+// CheckBigqueryStorageBilling returns an error if the storage billing model is unknown
+func CheckBigqueryStorageBilling(inputBilling string) error {
+	if inputBilling != BigqueryStorageLogical && inputBilling != BigqueryStoragePhysical {
+		return &ResourceError{Resource: "BigQuery storage billing model", Name: inputBilling}
+	}
+	return nil
+}
+
+func CostBigqueryAnalysis(pricingYml StructPricing, inputRegion string) (Cost, error) {
+	cost, ok := pricingYml.Bigquery.Analysis.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "BigQuery on-demand analysis", Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostBigqueryEdition(pricingYml StructPricing, inputEdition string, inputRegion string) (Cost, error) {
+	edition, err := CheckBigqueryEdition(pricingYml, inputEdition)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := edition.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "BigQuery edition", Name: inputEdition, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostBigqueryStorage(pricingYml StructPricing, inputStorageType string, inputRegion string) (Cost, error) {
+	storage, ok := pricingYml.Bigquery.Storage[inputStorageType]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "BigQuery storage", Name: inputStorageType}
+	}
+	cost, ok := storage.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "BigQuery storage", Name: inputStorageType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostBigqueryStreaming(pricingYml StructPricing, inputRegion string) (Cost, error) {
+	cost, ok := pricingYml.Bigquery.Streaming.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "BigQuery streaming inserts", Region: inputRegion}
+	}
+	return cost, nil
+}
+
+// ReturnBigqueryStorageTypes returns the active and long-term storage type of the storage billing model
+func ReturnBigqueryStorageTypes(inputBilling string) []string {
+	return []string{"active-" + inputBilling, "long-term-" + inputBilling}
+}
+
+func (e *Estimate) returnBigqueryName(defaultName string, inputName string) string {
+	var name string
+	if len(defaultName) > 0 {
+		name = defaultName
+	} else {
+		name = "default-bigquery-name"
+	}
+	if len(inputName) > 0 {
+		name = inputName
+	}
+	e.info("BigQuery name: '%s'", name)
+	return name
+}
+
+// appendBigqueryTiered prices the tiered usage and appends the line item.
+// Line items within the free usage are kept for the aggregation of the tiered usage and removed by AggregateTiers.
+func (e *Estimate) appendBigqueryTiered(name string, inputType string, inputData float32, free float32, month float32, inputRegion string, discount float32, discountText string) float32 {
+	tiers := returnFreeTiers(free, month)
+	price := TieredPrice(tiers, inputData) * discount
+	e.info("Price '%s' BigQuery '%s' %.2f (%.2f free) per month: $%.2f %s", name, inputType, inputData, free, price, discountText)
+	e.LineItems = append(e.LineItems, LineItem{
+		File:     e.File,
+		Project:  e.Project,
+		Name:     name,
+		Type:     inputType,
+		Data:     inputData,
+		Region:   inputRegion,
+		Resource: "bigquery",
+		Discount: discount,
+		Cost:     price,
+
+		tiers:          tiers,
+		billingAccount: e.BillingAccount,
+		free:           free > 0,
+	})
+	return price
+}
+
+// CalcBigqueryAnalysis calculates the on-demand analysis per TiB scanned with the free TiB per month
+func (e *Estimate) CalcBigqueryAnalysis(inputName string, inputData float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnBigqueryName("", inputName)
+	discount, discountText := returnDiscount(inputDiscount)
+	if !(inputData > 0) {
+		return 0, nil
+	}
+	month, err := costMonth(CostBigqueryAnalysis(e.Pricing, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("BigQuery on-demand analysis in region '%s' found.", inputRegion)
+	return e.appendBigqueryTiered(name, "analysis", inputData, e.Pricing.Bigquery.Analysis.Free, month, inputRegion, discount, discountText), nil
+}
+
+// CalcBigquerySlots calculates the capacity of an edition.
+// The baseline slots are charged the whole month and are covered by the commitment,
+// the autoscaled slots (maximum minus baseline slots) are charged per hour they are used.
+func (e *Estimate) CalcBigquerySlots(inputName string, inputEdition string, inputBaseline int, inputMax int, inputAutoscaleHours float32, inputRegion string, inputDiscount float32, inputCommitment int) (float32, error) {
+	name := e.returnBigqueryName("", inputName)
+	edition := BigqueryEditionStandard
+	if len(inputEdition) > 0 {
+		edition = inputEdition
+	}
+	cost, err := CostBigqueryEdition(e.Pricing, edition, inputRegion)
+	if err != nil {
+		return 0, err
+	}
+	e.success("BigQuery edition '%s' in region '%s' found.", edition, inputRegion)
+	commitment := e.returnComputeInstanceCommitment(inputCommitment)
+	discount, discountText := returnDiscount(inputDiscount)
+	var price float32
+	if inputBaseline > 0 {
+		var month float32
+		switch commitment {
+		case 1:
+			month, err = e.month1Y(cost)
+		case 3:
+			month, err = e.month3Y(cost)
+		default:
+			month, err = Month(cost)
+		}
+		if err != nil {
+			return 0, err
+		}
+		baseline := (month * float32(inputBaseline)) * discount
+		e.info("Price '%s' BigQuery %d baseline slots per month: $%.2f %s", name, inputBaseline, baseline, discountText)
+		e.LineItems = append(e.LineItems, LineItem{
+			File:       e.File,
+			Project:    e.Project,
+			Name:       name,
+			Type:       edition + "-baseline",
+			Data:       float32(inputBaseline),
+			Region:     inputRegion,
+			Resource:   "bigquery",
+			Commitment: commitment,
+			Discount:   discount,
+			Cost:       baseline,
+		})
+		price += baseline
+	}
+	autoscale := inputMax - inputBaseline
+	if autoscale > 0 && inputAutoscaleHours > 0 {
+		hour, err := Hour(cost)
+		if err != nil {
+			return 0, err
+		}
+		slotHours := float32(autoscale) * inputAutoscaleHours
+		priceAutoscale := (hour * slotHours) * discount
+		e.info("Price '%s' BigQuery %.0f autoscaled slot-hours per month: $%.2f %s", name, slotHours, priceAutoscale, discountText)
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Name:     name,
+			Type:     edition + "-autoscale",
+			Data:     slotHours,
+			Region:   inputRegion,
+			Resource: "bigquery",
+			Discount: discount,
+			Cost:     priceAutoscale,
+		})
+		price += priceAutoscale
+	}
+	return price, nil
+}
+
+// CalcBigqueryStorage calculates the active and long-term storage with the logical or physical storage billing model
+// and the streaming inserts
+func (e *Estimate) CalcBigqueryStorage(inputName string, inputBilling string, inputActive float32, inputLongTerm float32, inputStreaming float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnBigqueryName("", inputName)
+	billing := BigqueryStorageLogical
+	if len(inputBilling) > 0 {
+		billing = inputBilling
+	}
+	if err := CheckBigqueryStorageBilling(billing); err != nil {
+		return 0, err
+	}
+	discount, discountText := returnDiscount(inputDiscount)
+	var price float32
+	storageTypes := ReturnBigqueryStorageTypes(billing)
+	for i, data := range []float32{inputActive, inputLongTerm} {
+		if !(data > 0) {
+			continue
+		}
+		storageType := storageTypes[i]
+		month, err := costMonth(CostBigqueryStorage(e.Pricing, storageType, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("BigQuery storage '%s' in region '%s' found.", storageType, inputRegion)
+		free := e.Pricing.Bigquery.Storage[storageType].Free
+		price += e.appendBigqueryTiered(name, storageType, data, free, month, inputRegion, discount, discountText)
+	}
+	if inputStreaming > 0 {
+		month, err := costMonth(CostBigqueryStreaming(e.Pricing, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("BigQuery streaming inserts in region '%s' found.", inputRegion)
+		streaming := (month * inputStreaming) * discount
+		e.info("Price '%s' BigQuery streaming inserts %.2f GiB per month: $%.2f %s", name, inputStreaming, streaming, discountText)
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Name:     name,
+			Type:     "streaming",
+			Data:     inputStreaming,
+			Region:   inputRegion,
+			Resource: "bigquery",
+			Discount: discount,
+			Cost:     streaming,
+		})
+		price += streaming
+	}
+	return price, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+func TestCalcBigqueryAnalysis(t *testing.T) {
+	pricingYml := testPricing(t, `
+bigquery:
+  analysis:
+    free: 1
+    cost:
+      us-multi: {month: 6.25}
+      europe-multi: {month: 7}
+`)
+	type analysis struct {
+		region string
+		data   float32 // TiB scanned
+	}
+	tests := []struct {
+		name     string
+		analyses []analysis
+		want     []float32 // Cost of the line items
+	}{
+		{"within free usage", []analysis{{"us-multi", 0.5}}, nil},
+		{"above free usage", []analysis{{"us-multi", 3}}, []float32{2 * 6.25}},
+		{"free usage shared by regions", []analysis{{"us-multi", 1}, {"europe-multi", 1}}, []float32{0.5 * 6.25, 0.5 * 7}},
+		{"free usage covers regions", []analysis{{"us-multi", 0.5}, {"europe-multi", 0.5}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			for _, a := range tt.analyses {
+				if _, err := e.CalcBigqueryAnalysis("bq", a.data, a.region, 1); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := e.AggregateTiers(TierScopeTotal); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(e.LineItems) != len(tt.want) {
+				t.Fatalf("line items = %d, want %d", len(e.LineItems), len(tt.want))
+			}
+			for i, lineItem := range e.LineItems {
+				if !testEqual(lineItem.Cost, tt.want[i]) {
+					t.Errorf("cost of line item %d in '%s' = %f, want %f", i, lineItem.Region, lineItem.Cost, tt.want[i])
+				}
+			}
+		})
+	}
+}
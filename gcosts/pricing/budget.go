@@ -0,0 +1,117 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+)
+
+// Scope of a budget
+const (
+	BudgetTotal   = "total"
+	BudgetFile    = "file"
+	BudgetProject = "project"
+)
+
+// Budget is the monthly budget for the costs of all line items, of one usage file or of one project
+type Budget struct {
+	Scope  string  // BudgetTotal, BudgetFile or BudgetProject
+	Name   string  // Name of the usage file or project
+	Amount float32 // Maximum costs per month (0 = no maximum)
+	Growth float32 // Maximum growth of the costs in percent compared to the baseline (0 = no maximum)
+}
+
+// BudgetCheck is the result of the check of one budget
+type BudgetCheck struct {
+	Scope    string  `json:"scope" yaml:"scope"`
+	Name     string  `json:"name" yaml:"name"`
+	Amount   float32 `json:"amount" yaml:"amount"`
+	Growth   float32 `json:"growth" yaml:"growth"`
+	Cost     float32 `json:"cost" yaml:"cost"`
+	Baseline float32 `json:"baseline" yaml:"baseline"`
+	Exceeded bool    `json:"exceeded" yaml:"exceeded"`
+	Message  string  `json:"message" yaml:"message"`
+}
+
+// AddBudget adds a budget which is checked with CheckBudgets.
+// A budget with the same scope and name is replaced. Budgets without amount and growth are ignored.
+func (e *Estimate) AddBudget(budget Budget) {
+	if !(budget.Amount > 0 || budget.Growth > 0) {
+		return
+	}
+	for i, b := range e.Budgets {
+		if b.Scope == budget.Scope && b.Name == budget.Name {
+			e.Budgets[i] = budget
+			return
+		}
+	}
+	e.Budgets = append(e.Budgets, budget)
+}
+
+// CheckBudgets checks the costs of the line items against the budgets.
+// The growth is only checked if there are baseline line items (e.g. of a previous CSV export) for the scope.
+func (e *Estimate) CheckBudgets(baseline []LineItem) []BudgetCheck {
+	checks := []BudgetCheck{}
+	for _, budget := range e.Budgets {
+		check := BudgetCheck{
+			Scope:    budget.Scope,
+			Name:     budget.Name,
+			Amount:   budget.Amount,
+			Growth:   budget.Growth,
+			Cost:     budgetCost(e.LineItems, budget),
+			Baseline: budgetCost(baseline, budget),
+		}
+		scope := "all usage files"
+		if budget.Scope != BudgetTotal {
+			scope = fmt.Sprintf("%s '%s'", budget.Scope, budget.Name)
+		}
+		growth := float32(0)
+		if check.Baseline > 0 {
+			growth = (check.Cost - check.Baseline) / check.Baseline * 100
+		}
+		switch {
+		case budget.Amount > 0 && check.Cost > budget.Amount:
+			check.Exceeded = true
+			check.Message = fmt.Sprintf("Costs of %s exceed the budget: $%.2f > $%.2f", scope, check.Cost, budget.Amount)
+		case budget.Growth > 0 && growth > budget.Growth:
+			check.Exceeded = true
+			check.Message = fmt.Sprintf("Costs of %s grow more than %.2f%%: $%.2f → $%.2f (%+.2f%%)", scope, budget.Growth, check.Baseline, check.Cost, growth)
+		case budget.Amount > 0:
+			check.Message = fmt.Sprintf("Costs of %s within the budget: $%.2f <= $%.2f", scope, check.Cost, budget.Amount)
+		case check.Baseline > 0:
+			check.Message = fmt.Sprintf("Costs of %s grow not more than %.2f%%: $%.2f → $%.2f (%+.2f%%)", scope, budget.Growth, check.Baseline, check.Cost, growth)
+		default:
+			check.Message = fmt.Sprintf("Growth of the costs of %s not checked without baseline", scope)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// budgetCost returns the sum of the costs of the line items in the scope of the budget
+func budgetCost(lineItems []LineItem, budget Budget) float32 {
+	var cost float32
+	for _, lineItem := range lineItems {
+		switch {
+		case budget.Scope == BudgetFile && lineItem.File != budget.Name:
+			continue
+		case budget.Scope == BudgetProject && lineItem.Project != budget.Name:
+			continue
+		}
+		cost += lineItem.Cost
+	}
+	return cost
+}
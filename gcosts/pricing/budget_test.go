@@ -0,0 +1,153 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddBudget(t *testing.T) {
+	estimate := Estimate{}
+	estimate.AddBudget(Budget{Scope: BudgetFile, Name: "a.yml", Amount: 100})
+	estimate.AddBudget(Budget{Scope: BudgetProject, Name: "a.yml", Amount: 200})
+	estimate.AddBudget(Budget{Scope: BudgetFile, Name: "b.yml"})
+	estimate.AddBudget(Budget{Scope: BudgetFile, Name: "a.yml", Growth: 10})
+	want := []Budget{
+		{Scope: BudgetFile, Name: "a.yml", Growth: 10},
+		{Scope: BudgetProject, Name: "a.yml", Amount: 200},
+	}
+	if len(estimate.Budgets) != len(want) {
+		t.Fatalf("budgets = %+v, want %+v", estimate.Budgets, want)
+	}
+	for i := range want {
+		if estimate.Budgets[i] != want[i] {
+			t.Errorf("budget %d = %+v, want %+v", i, estimate.Budgets[i], want[i])
+		}
+	}
+}
+
+func TestCheckBudgets(t *testing.T) {
+	lineItems := []LineItem{
+		{Project: "p1", File: "a.yml", Cost: 60},
+		{Project: "p1", File: "b.yml", Cost: 30},
+		{Project: "p2", File: "c.yml", Cost: 10},
+	}
+	baseline := []LineItem{
+		{Project: "p1", File: "a.yml", Cost: 50},
+		{Project: "p2", File: "c.yml", Cost: 10},
+	}
+	tests := []struct {
+		name         string
+		budget       Budget
+		baseline     []LineItem
+		wantCost     float32
+		wantBaseline float32
+		wantExceeded bool
+		wantMessage  string
+	}{
+		{
+			name:        "total within the budget",
+			budget:      Budget{Scope: BudgetTotal, Amount: 100},
+			wantCost:    100,
+			wantMessage: "Costs of all usage files within the budget: $100.00 <= $100.00",
+		},
+		{
+			name:         "total exceeded",
+			budget:       Budget{Scope: BudgetTotal, Amount: 99.99},
+			wantCost:     100,
+			wantExceeded: true,
+			wantMessage:  "Costs of all usage files exceed the budget: $100.00 > $99.99",
+		},
+		{
+			name:         "file matched by name",
+			budget:       Budget{Scope: BudgetFile, Name: "b.yml", Amount: 20},
+			wantCost:     30,
+			wantExceeded: true,
+			wantMessage:  "Costs of file 'b.yml' exceed the budget",
+		},
+		{
+			name:        "project matched by name",
+			budget:      Budget{Scope: BudgetProject, Name: "p1", Amount: 90},
+			wantCost:    90,
+			wantMessage: "Costs of project 'p1' within the budget",
+		},
+		{
+			name:        "unknown project",
+			budget:      Budget{Scope: BudgetProject, Name: "a.yml", Amount: 1},
+			wantMessage: "Costs of project 'a.yml' within the budget: $0.00 <= $1.00",
+		},
+		{
+			name:         "growth exceeded",
+			budget:       Budget{Scope: BudgetFile, Name: "a.yml", Growth: 10},
+			baseline:     baseline,
+			wantCost:     60,
+			wantBaseline: 50,
+			wantExceeded: true,
+			wantMessage:  "Costs of file 'a.yml' grow more than 10.00%: $50.00 → $60.00 (+20.00%)",
+		},
+		{
+			name:         "growth not exceeded",
+			budget:       Budget{Scope: BudgetProject, Name: "p1", Growth: 80},
+			baseline:     baseline,
+			wantCost:     90,
+			wantBaseline: 50,
+			wantMessage:  "Costs of project 'p1' grow not more than 80.00%: $50.00 → $90.00 (+80.00%)",
+		},
+		{
+			name:         "growth within the budget exceeded",
+			budget:       Budget{Scope: BudgetTotal, Amount: 200, Growth: 5},
+			baseline:     baseline,
+			wantCost:     100,
+			wantBaseline: 60,
+			wantExceeded: true,
+			wantMessage:  "grow more than 5.00%",
+		},
+		{
+			name:        "growth without baseline",
+			budget:      Budget{Scope: BudgetFile, Name: "a.yml", Growth: 10},
+			wantCost:    60,
+			wantMessage: "Growth of the costs of file 'a.yml' not checked without baseline",
+		},
+		{
+			name:        "growth without baseline of the file",
+			budget:      Budget{Scope: BudgetFile, Name: "b.yml", Growth: 10},
+			baseline:    baseline,
+			wantCost:    30,
+			wantMessage: "Growth of the costs of file 'b.yml' not checked without baseline",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimate := Estimate{LineItems: lineItems}
+			estimate.AddBudget(test.budget)
+			checks := estimate.CheckBudgets(test.baseline)
+			if len(checks) != 1 {
+				t.Fatalf("checks = %+v, want one check", checks)
+			}
+			check := checks[0]
+			if !testEqual(check.Cost, test.wantCost) || !testEqual(check.Baseline, test.wantBaseline) {
+				t.Errorf("cost = %v, baseline = %v, want %v, %v", check.Cost, check.Baseline, test.wantCost, test.wantBaseline)
+			}
+			if check.Exceeded != test.wantExceeded {
+				t.Errorf("exceeded = %v, want %v", check.Exceeded, test.wantExceeded)
+			}
+			if !strings.Contains(check.Message, test.wantMessage) {
+				t.Errorf("message = %q, want %q", check.Message, test.wantMessage)
+			}
+		})
+	}
+}
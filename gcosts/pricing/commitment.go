@@ -0,0 +1,191 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Discounts of the Compute flexible committed use discounts (spend-based)
+// https://cloud.google.com/compute/docs/instances/committed-use-discounts-overview
+const (
+	FlexibleCommitment1Y float32 = 0.28
+	FlexibleCommitment3Y float32 = 0.46
+)
+
+// Machine families that are not covered by flexible commitments
+var flexibleCommitmentExcluded = map[string]bool{
+	"m1": true, "m2": true, "m3": true, "m4": true, // Memory-optimized
+	"a2": true, "a3": true, // Accelerator-optimized
+	"f1": true, "g1": true, // Shared-core N1
+}
+
+// FlexibleCommitmentEligible returns true if the machine type is covered by flexible commitments
+func FlexibleCommitmentEligible(inputMachineType string) bool {
+	family, _, _ := strings.Cut(inputMachineType, "-")
+	return !flexibleCommitmentExcluded[family]
+}
+
+// returnOnDemand returns the on-demand spend of the runtime in hours per month (0 for a full month)
+// without sustained use discount and discount
+func returnOnDemand(cost Cost, inputHours float32, inputSud float32) float32 {
+	if cost.Hour > 0 {
+		hours := inputHours
+		if !(hours > 0) {
+			hours = HoursMonth
+		}
+		return cost.Hour * hours
+	}
+	// Monthly price includes the sustained use discount
+	if inputSud > 0 {
+		return cost.Month / inputSud
+	}
+	return cost.Month
+}
+
+// Commitment is a flexible (spend-based) committed use discount.
+// The hourly on-demand spend of eligible VMs across machine families and regions of the billing account
+// is covered up to the amount and billed with the discount.
+type Commitment struct {
+	Name           string
+	Term           int     // 1 or 3 years
+	Amount         float32 // Committed on-demand spend per hour
+	BillingAccount string
+	Project        string // Project and usage file of the line items of the commitment
+	File           string
+	Discount       float32 // Discount of the usage file for the unused commitment
+}
+
+// CommitmentUsage is the result of the application of one commitment
+type CommitmentUsage struct {
+	Name        string  `json:"name" yaml:"name"`
+	Term        int     `json:"term" yaml:"term"`
+	Amount      float32 `json:"amount" yaml:"amount"`           // Committed on-demand spend per month
+	Eligible    float32 `json:"eligible" yaml:"eligible"`       // Eligible on-demand spend per month
+	Covered     float32 `json:"covered" yaml:"covered"`         // Covered on-demand spend per month
+	Coverage    float32 `json:"coverage" yaml:"coverage"`       // Covered of eligible spend in percent
+	Utilization float32 `json:"utilization" yaml:"utilization"` // Covered of committed spend in percent
+	Cost        float32 `json:"cost" yaml:"cost"`               // Costs of the commitment per month
+	Unused      float32 `json:"unused" yaml:"unused"`           // Costs of the unused commitment per month
+}
+
+// AddCommitment adds a flexible commitment of the billing account, project and usage file of the estimate.
+// The commitment is applied with ApplyCommitments.
+func (e *Estimate) AddCommitment(inputName string, inputTerm int, inputAmount float32) error {
+	if _, err := returnFlexibleCommitmentDiscount(inputTerm); err != nil {
+		return err
+	}
+	if !(inputAmount > 0) {
+		return fmt.Errorf("amount of commitment '%s' must be more than 0", inputName)
+	}
+	name := inputName
+	if len(name) == 0 {
+		name = "default-commitment"
+	}
+	discount, discountText := returnDiscount(e.Discount)
+	e.info("Flexible commitment '%s': %d year(s), $%.2f on-demand spend per hour %s", name, inputTerm, inputAmount, discountText)
+	e.Commitments = append(e.Commitments, Commitment{
+		Name:           name,
+		Term:           inputTerm,
+		Amount:         inputAmount,
+		BillingAccount: e.BillingAccount,
+		Project:        e.Project,
+		File:           e.File,
+		Discount:       discount,
+	})
+	return nil
+}
+
+func returnFlexibleCommitmentDiscount(inputTerm int) (float32, error) {
+	switch inputTerm {
+	case 1:
+		return FlexibleCommitment1Y, nil
+	case 3:
+		return FlexibleCommitment3Y, nil
+	}
+	return 0, fmt.Errorf("term '%d' of flexible commitment not supported, use 1 or 3", inputTerm)
+}
+
+// ApplyCommitments applies the flexible commitments in the order they were added to the eligible
+// on-demand VM line items of the same billing account in billing order (order of the line items).
+// Commitments cover the on-demand spend without sustained use discount and discount.
+// The covered share is removed from the costs of the line items and billed with the discounted price of the commitment
+// and the discount of the line items. The unused commitment is added as its own line item with the discount of the usage file.
+func (e *Estimate) ApplyCommitments() []CommitmentUsage {
+	var usages []CommitmentUsage
+	// Commitments are not part of one usage file
+	file := e.File
+	e.File = ""
+	defer func() { e.File = file }()
+	for _, commitment := range e.Commitments {
+		// Validated by AddCommitment
+		discount, _ := returnFlexibleCommitmentDiscount(commitment.Term)
+		usage := CommitmentUsage{
+			Name:   commitment.Name,
+			Term:   commitment.Term,
+			Amount: commitment.Amount * HoursMonth,
+		}
+		for i := range e.LineItems {
+			lineItem := &e.LineItems[i]
+			if !lineItem.flexible || lineItem.billingAccount != commitment.BillingAccount {
+				continue
+			}
+			usage.Eligible = usage.Eligible + lineItem.onDemand
+			covered := min(lineItem.onDemand, usage.Amount-usage.Covered)
+			if covered > 0 {
+				// Costs with sustained use discount and discount of the line item per on-demand spend
+				rate := lineItem.Cost / lineItem.onDemand
+				lineItem.Cost = lineItem.Cost - covered*rate
+				lineItem.onDemand = lineItem.onDemand - covered
+				usage.Covered = usage.Covered + covered
+				usage.Cost = usage.Cost + covered*lineItem.Discount*(1-discount)
+			}
+		}
+		if usage.Eligible > 0 {
+			usage.Coverage = usage.Covered / usage.Eligible * 100
+		}
+		usage.Utilization = usage.Covered / usage.Amount * 100
+		usage.Unused = (usage.Amount - usage.Covered) * (1 - discount) * commitment.Discount
+		e.info("Flexible commitment '%s': coverage %.2f%%, utilization %.2f%%", usage.Name, usage.Coverage, usage.Utilization)
+		if usage.Unused > 0 {
+			e.warning("Flexible commitment '%s' is not fully used: $%.2f unused per month", usage.Name, usage.Unused)
+		}
+		for _, item := range []struct {
+			kind string
+			cost float32
+		}{
+			{fmt.Sprintf("flexible-%dy", commitment.Term), usage.Cost},
+			{fmt.Sprintf("flexible-%dy-unused", commitment.Term), usage.Unused},
+		} {
+			if item.cost > 0 {
+				e.LineItems = append(e.LineItems, LineItem{
+					File:       commitment.File,
+					Project:    commitment.Project,
+					Name:       commitment.Name,
+					Type:       item.kind,
+					Data:       commitment.Amount,
+					Resource:   "commitment",
+					Commitment: commitment.Term,
+					Discount:   commitment.Discount,
+					Cost:       item.cost,
+				})
+			}
+		}
+		usages = append(usages, usage)
+	}
+	return usages
+}
@@ -0,0 +1,237 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+func TestApplyCommitments(t *testing.T) {
+	pricingYml := testPricing(t, `
+compute:
+  instance:
+    n1-standard-1:
+      cost:
+        us-central1: {hour: 0.1, month: 51.1}
+    e2-standard-2:
+      cost:
+        us-central1: {hour: 0.1, month: 73}
+    m1-megamem-96:
+      cost:
+        us-central1: {hour: 10, month: 5110}
+`)
+	type instance struct {
+		machineType    string
+		billingAccount string
+	}
+	type commitment struct {
+		amount         float32 // Per hour
+		billingAccount string
+	}
+	tests := []struct {
+		name        string
+		instances   []instance
+		commitments []commitment
+		wantCovered []float32 // Per commitment
+		wantCost    []float32 // Per instance
+	}{
+		{
+			name:        "partial utilization",
+			instances:   []instance{{"e2-standard-2", ""}},
+			commitments: []commitment{{0.2, ""}},
+			wantCovered: []float32{73},
+			wantCost:    []float32{0},
+		},
+		{
+			name:        "on-demand price without sustained use discount",
+			instances:   []instance{{"n1-standard-1", ""}},
+			commitments: []commitment{{0.05, ""}},
+			wantCovered: []float32{36.5},
+			wantCost:    []float32{51.1 / 2},
+		},
+		{
+			name:        "two commitments of one billing account",
+			instances:   []instance{{"e2-standard-2", "a"}, {"e2-standard-2", "a"}},
+			commitments: []commitment{{0.1, "a"}, {0.05, "a"}},
+			wantCovered: []float32{73, 36.5},
+			wantCost:    []float32{0, 36.5},
+		},
+		{
+			name:        "billing account mismatch",
+			instances:   []instance{{"e2-standard-2", "a"}, {"e2-standard-2", "b"}},
+			commitments: []commitment{{0.2, "b"}},
+			wantCovered: []float32{73},
+			wantCost:    []float32{73, 0},
+		},
+		{
+			name:        "memory-optimized machine type not covered",
+			instances:   []instance{{"m1-megamem-96", ""}},
+			commitments: []commitment{{0.1, ""}},
+			wantCovered: []float32{0},
+			wantCost:    []float32{5110},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			for _, i := range tt.instances {
+				e.BillingAccount = i.billingAccount
+				if _, err := e.CalcComputeInstance("vm", i.machineType, "us-central1", 1, 0, false, false, 0); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			for _, c := range tt.commitments {
+				e.BillingAccount = c.billingAccount
+				if err := e.AddCommitment("cud", 1, c.amount); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			usages := e.ApplyCommitments()
+			if len(usages) != len(tt.wantCovered) {
+				t.Fatalf("commitments = %d, want %d", len(usages), len(tt.wantCovered))
+			}
+			for i, usage := range usages {
+				if !testEqual(usage.Covered, tt.wantCovered[i]) {
+					t.Errorf("covered of commitment %d = %f, want %f", i, usage.Covered, tt.wantCovered[i])
+				}
+				if want := tt.wantCovered[i] / usage.Amount * 100; !testEqual(usage.Utilization, want) {
+					t.Errorf("utilization of commitment %d = %f, want %f", i, usage.Utilization, want)
+				}
+				if want := tt.wantCovered[i] * (1 - FlexibleCommitment1Y); !testEqual(usage.Cost, want) {
+					t.Errorf("cost of commitment %d = %f, want %f", i, usage.Cost, want)
+				}
+				if want := (usage.Amount - tt.wantCovered[i]) * (1 - FlexibleCommitment1Y); !testEqual(usage.Unused, want) {
+					t.Errorf("unused of commitment %d = %f, want %f", i, usage.Unused, want)
+				}
+			}
+			for i, want := range tt.wantCost {
+				if lineItem := e.LineItems[i]; !testEqual(lineItem.Cost, want) {
+					t.Errorf("cost of instance %d = %f, want %f", i, lineItem.Cost, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFlexibleCommitmentEligible(t *testing.T) {
+	tests := []struct {
+		machineType string
+		want        bool
+	}{
+		{"n2-standard-4", true},
+		{"e2-medium", true},
+		{"custom-2-15360", true},
+		{"n2-custom-4-16384", true},
+		{"c3-standard-8", true},
+		{"m1-megamem-96", false},
+		{"m2-ultramem-208", false},
+		{"m3-megamem-64", false},
+		{"a2-highgpu-1g", false},
+		{"a3-highgpu-8g", false},
+		{"f1-micro", false},
+		{"g1-small", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.machineType, func(t *testing.T) {
+			if got := FlexibleCommitmentEligible(tt.machineType); got != tt.want {
+				t.Errorf("FlexibleCommitmentEligible(%s) = %t, want %t", tt.machineType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCommitmentsDiscount(t *testing.T) {
+	pricingYml := testPricing(t, `
+compute:
+  instance:
+    n1-standard-1:
+      cost:
+        us-central1: {hour: 0.1, month: 51.1}
+    e2-standard-2:
+      cost:
+        us-central1: {hour: 0.1, month: 73}
+`)
+	tests := []struct {
+		name             string
+		machineType      string
+		instanceDiscount float32
+		amount           float32 // Per hour
+		fileDiscount     float32 // Discount of the usage file of the commitment
+		wantCost         float32 // Instance
+		wantCommitment   float32
+		wantUnused       float32
+	}{
+		{
+			name:             "discount of the line item and the commitment",
+			machineType:      "e2-standard-2",
+			instanceDiscount: 0.9,
+			amount:           0.05,
+			fileDiscount:     0.9,
+			wantCost:         36.5 * 0.9,
+			wantCommitment:   36.5 * 0.9 * (1 - FlexibleCommitment1Y),
+		},
+		{
+			name:             "discount with sustained use discount",
+			machineType:      "n1-standard-1",
+			instanceDiscount: 0.8,
+			amount:           0.05,
+			wantCost:         51.1 * 0.8 / 2,
+			wantCommitment:   36.5 * 0.8 * (1 - FlexibleCommitment1Y),
+		},
+		{
+			name:             "unused commitment with discount of the usage file",
+			machineType:      "e2-standard-2",
+			instanceDiscount: 0.5,
+			amount:           0.2,
+			fileDiscount:     0.9,
+			wantCost:         0,
+			wantCommitment:   73 * 0.5 * (1 - FlexibleCommitment1Y),
+			wantUnused:       73 * 0.9 * (1 - FlexibleCommitment1Y),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			if _, err := e.CalcComputeInstance("vm", tt.machineType, "us-central1", tt.instanceDiscount, 0, false, false, 0); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			e.Discount = tt.fileDiscount
+			if err := e.AddCommitment("cud", 1, tt.amount); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			usages := e.ApplyCommitments()
+			if len(usages) != 1 {
+				t.Fatalf("commitments = %d, want 1", len(usages))
+			}
+			if !testEqual(e.LineItems[0].Cost, tt.wantCost) {
+				t.Errorf("cost of instance = %f, want %f", e.LineItems[0].Cost, tt.wantCost)
+			}
+			if !testEqual(usages[0].Cost, tt.wantCommitment) {
+				t.Errorf("cost of commitment = %f, want %f", usages[0].Cost, tt.wantCommitment)
+			}
+			if !testEqual(usages[0].Unused, tt.wantUnused) {
+				t.Errorf("unused of commitment = %f, want %f", usages[0].Unused, tt.wantUnused)
+			}
+			var total float32
+			for _, lineItem := range e.LineItems {
+				total += lineItem.Cost
+			}
+			if want := tt.wantCost + tt.wantCommitment + tt.wantUnused; !testEqual(total, want) {
+				t.Errorf("total = %f, want %f", total, want)
+			}
+		})
+	}
+}
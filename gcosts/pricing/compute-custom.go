@@ -0,0 +1,187 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Google Compute Engine custom machine type
+
+// CustomMachineType is a parsed custom machine type, e.g. "n2-custom-6-20480" or "custom-2-15360-ext"
+type CustomMachineType struct {
+	Family      string  // Machine family, "n1" for custom machine types without prefix
+	Cpu         int     // Number of vCPUs
+	Ram         float32 // Memory in GiB without extended memory
+	RamExtended float32 // Extended memory in GiB
+}
+
+// customMachineFamily are the limits of the custom machine types of a machine family
+type customMachineFamily struct {
+	cpu         func(cpu int) bool // Valid number of vCPUs
+	cpuText     string
+	minRam      float32 // Minimum memory per vCPU in GiB
+	maxRam      float32 // Maximum memory per vCPU in GiB without extended memory
+	maxTotalRam float32 // Maximum memory in GiB without extended memory (0 = no limit)
+	maxExtended float32 // Maximum memory in GiB with extended memory (0 = not supported)
+}
+
+// https://cloud.google.com/compute/docs/instances/creating-instance-with-custom-machine-type
+var customMachineFamilies = map[string]customMachineFamily{
+	"n1": {
+		cpu: func(cpu int) bool {
+			return cpu == 1 || (cpu%2 == 0 && cpu >= 2 && cpu <= 96)
+		},
+		cpuText:     "1 or a multiple of 2 up to 96",
+		minRam:      0.9,
+		maxRam:      6.5,
+		maxExtended: 624,
+	},
+	"n2": {
+		cpu: func(cpu int) bool {
+			return (cpu%2 == 0 && cpu >= 2 && cpu <= 30) || (cpu%4 == 0 && cpu >= 32 && cpu <= 128)
+		},
+		cpuText:     "a multiple of 2 up to 30 or a multiple of 4 from 32 to 128",
+		minRam:      0.5,
+		maxRam:      8,
+		maxExtended: 864,
+	},
+	"n2d": {
+		cpu: func(cpu int) bool {
+			return cpu == 2 || cpu == 4 || cpu == 8 || (cpu%16 == 0 && cpu >= 16 && cpu <= 96)
+		},
+		cpuText:     "2, 4, 8 or a multiple of 16 up to 96",
+		minRam:      0.5,
+		maxRam:      8,
+		maxExtended: 768,
+	},
+	"n4": {
+		cpu: func(cpu int) bool {
+			return (cpu%2 == 0 && cpu >= 2 && cpu <= 16) || (cpu%4 == 0 && cpu >= 20 && cpu <= 80)
+		},
+		cpuText: "a multiple of 2 up to 16 or a multiple of 4 from 20 to 80",
+		minRam:  2,
+		maxRam:  8,
+	},
+	"e2": {
+		cpu: func(cpu int) bool {
+			return cpu%2 == 0 && cpu >= 2 && cpu <= 32
+		},
+		cpuText:     "a multiple of 2 up to 32",
+		minRam:      0.5,
+		maxRam:      8,
+		maxTotalRam: 128,
+	},
+}
+
+var customMachineTypeRegexp = regexp.MustCompile(`^(?:([a-z0-9]+)-)?custom-(\d+)-(\d+)(-ext)?$`)
+
+// IsCustomMachineType returns true if the name of the machine type is a custom machine type
+func IsCustomMachineType(inputMachineType string) bool {
+	return customMachineTypeRegexp.MatchString(inputMachineType)
+}
+
+// CheckComputeCustomInstance parses the custom machine type and checks the limits of the machine family
+func CheckComputeCustomInstance(inputMachineType string) (CustomMachineType, error) {
+	match := customMachineTypeRegexp.FindStringSubmatch(inputMachineType)
+	if match == nil {
+		return CustomMachineType{}, &ResourceError{Resource: "Google Compute Engine machine type", Name: inputMachineType}
+	}
+	custom := CustomMachineType{Family: match[1]}
+	if len(custom.Family) == 0 {
+		custom.Family = "n1"
+	}
+	extended := len(match[4]) > 0
+	family, ok := customMachineFamilies[custom.Family]
+	if !ok {
+		return CustomMachineType{}, &MachineTypeError{Name: inputMachineType, Reason: fmt.Sprintf("machine family '%s' does not support custom machine types", custom.Family)}
+	}
+	cpu, err := strconv.Atoi(match[2])
+	if err != nil || !family.cpu(cpu) {
+		return CustomMachineType{}, &MachineTypeError{Name: inputMachineType, Reason: fmt.Sprintf("number of vCPUs must be %s", family.cpuText)}
+	}
+	custom.Cpu = cpu
+	mib, err := strconv.Atoi(match[3])
+	if err != nil || mib%256 != 0 {
+		return CustomMachineType{}, &MachineTypeError{Name: inputMachineType, Reason: "memory must be a multiple of 256 MiB"}
+	}
+	ram := float32(mib) / 1024
+	if ram < family.minRam*float32(cpu) {
+		return CustomMachineType{}, &MachineTypeError{Name: inputMachineType, Reason: fmt.Sprintf("memory must be at least %.1f GiB per vCPU", family.minRam)}
+	}
+	maxRam := family.maxRam * float32(cpu)
+	if family.maxTotalRam > 0 {
+		maxRam = min(maxRam, family.maxTotalRam)
+	}
+	if extended && !(family.maxExtended > 0) {
+		return CustomMachineType{}, &MachineTypeError{Name: inputMachineType, Reason: fmt.Sprintf("machine family '%s' does not support extended memory", custom.Family)}
+	} else if extended && ram > family.maxExtended {
+		return CustomMachineType{}, &MachineTypeError{Name: inputMachineType, Reason: fmt.Sprintf("memory must not be more than %.0f GiB", family.maxExtended)}
+	} else if !extended && ram > maxRam {
+		return CustomMachineType{}, &MachineTypeError{Name: inputMachineType, Reason: fmt.Sprintf("memory must not be more than %.1f GiB without extended memory", maxRam)}
+	}
+	custom.Ram = min(ram, maxRam)
+	custom.RamExtended = ram - custom.Ram
+	return custom, nil
+}
+
+// returnCustomPrice returns the price of all vCPUs and the memory.
+// Returns 0 if one of the prices is missing.
+func returnCustomPrice(custom CustomMachineType, inputCpu float32, inputRam float32, inputRamExtended float32) float32 {
+	if !(inputCpu > 0) || !(inputRam > 0) || (custom.RamExtended > 0 && !(inputRamExtended > 0)) {
+		return 0
+	}
+	return inputCpu*float32(custom.Cpu) + inputRam*custom.Ram + inputRamExtended*custom.RamExtended
+}
+
+// CostComputeCustomInstance calculates the costs of the custom machine type
+// from the price per vCPU and per GiB memory of the machine family
+func CostComputeCustomInstance(pricingYml StructPricing, inputMachineType string, inputRegion string) (Cost, error) {
+	custom, err := CheckComputeCustomInstance(inputMachineType)
+	if err != nil {
+		return Cost{}, err
+	}
+	family, ok := pricingYml.Compute.Custom[custom.Family]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Custom machine types of GCE machine family", Name: custom.Family}
+	}
+	cpu, ok := family.Cpu.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE machine type", Name: inputMachineType, Region: inputRegion}
+	}
+	ram, ok := family.Ram.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE machine type", Name: inputMachineType, Region: inputRegion}
+	}
+	var extended Cost
+	if custom.RamExtended > 0 {
+		extended, ok = family.RamExtended.Cost[inputRegion]
+		if !ok {
+			return Cost{}, &ResourceError{Resource: "Extended memory of GCE machine type", Name: inputMachineType, Region: inputRegion}
+		}
+	}
+	return Cost{
+		Hour:      returnCustomPrice(custom, cpu.Hour, ram.Hour, extended.Hour),
+		HourSpot:  returnCustomPrice(custom, cpu.HourSpot, ram.HourSpot, extended.HourSpot),
+		Month:     returnCustomPrice(custom, cpu.Month, ram.Month, extended.Month),
+		MonthSpot: returnCustomPrice(custom, cpu.MonthSpot, ram.MonthSpot, extended.MonthSpot),
+		// Extended memory is not covered by commitments and billed with the on-demand price without sustained use discount
+		Month1Y: returnCustomPrice(custom, cpu.Month1Y, ram.Month1Y, extended.Hour*HoursMonth),
+		Month3Y: returnCustomPrice(custom, cpu.Month3Y, ram.Month3Y, extended.Hour*HoursMonth),
+	}, nil
+}
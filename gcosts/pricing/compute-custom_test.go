@@ -0,0 +1,143 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckComputeCustomInstance(t *testing.T) {
+	tests := []struct {
+		machineType string
+		want        CustomMachineType
+		wantErr     bool
+	}{
+		{"custom-2-13312", CustomMachineType{Family: "n1", Cpu: 2, Ram: 13}, false},
+		{"custom-1-1024", CustomMachineType{Family: "n1", Cpu: 1, Ram: 1}, false},
+		{"custom-2-15360-ext", CustomMachineType{Family: "n1", Cpu: 2, Ram: 13, RamExtended: 2}, false},
+		{"n2-custom-6-20480", CustomMachineType{Family: "n2", Cpu: 6, Ram: 20}, false},
+		{"n2-custom-2-20480-ext", CustomMachineType{Family: "n2", Cpu: 2, Ram: 16, RamExtended: 4}, false},
+		{"n2d-custom-16-65536", CustomMachineType{Family: "n2d", Cpu: 16, Ram: 64}, false},
+		{"e2-custom-32-131072", CustomMachineType{Family: "e2", Cpu: 32, Ram: 128}, false},
+		{"n4-custom-20-81920", CustomMachineType{Family: "n4", Cpu: 20, Ram: 80}, false},
+		{"custom-2-15360", CustomMachineType{}, true},         // More than 6.5 GiB per vCPU without extended memory
+		{"custom-3-3072", CustomMachineType{}, true},          // Odd number of vCPUs
+		{"n2-custom-34-34816", CustomMachineType{}, true},     // Not a multiple of 4 above 32
+		{"n2d-custom-6-6144", CustomMachineType{}, true},      // Not 2, 4, 8 or a multiple of 16
+		{"n2-custom-2-1000", CustomMachineType{}, true},       // Not a multiple of 256 MiB
+		{"n4-custom-2-2048", CustomMachineType{}, true},       // Less than 2 GiB per vCPU
+		{"e2-custom-32-262144", CustomMachineType{}, true},    // More than 128 GiB
+		{"e2-custom-2-20480-ext", CustomMachineType{}, true},  // No extended memory
+		{"n4-custom-2-20480-ext", CustomMachineType{}, true},  // No extended memory
+		{"n2-custom-2-900096-ext", CustomMachineType{}, true}, // More than 864 GiB
+		{"c3-custom-4-16384", CustomMachineType{}, true},      // No custom machine types
+	}
+	for _, tt := range tests {
+		t.Run(tt.machineType, func(t *testing.T) {
+			got, err := CheckComputeCustomInstance(tt.machineType)
+			if tt.wantErr {
+				var machineTypeError *MachineTypeError
+				if !errors.As(err, &machineTypeError) {
+					t.Fatalf("error = %v, want MachineTypeError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("custom machine type = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostComputeCustomInstance(t *testing.T) {
+	pricingYml := testPricing(t, `
+compute:
+  custom:
+    n1:
+      cpu:
+        cost:
+          us-central1: {hour: 0.03, month: 15.33, month_1y: 13, month_3y: 10}
+      ram:
+        cost:
+          us-central1: {hour: 0.004, month: 2.044, month_1y: 1.7, month_3y: 1.2}
+      ram-extended:
+        cost:
+          us-central1: {hour: 0.009, month: 4.599}
+    n2:
+      cpu:
+        cost:
+          us-central1: {hour: 0.03, month: 16.06, month_1y: 14, month_3y: 10}
+      ram:
+        cost:
+          us-central1: {hour: 0.004, month: 2.19, month_1y: 1.8, month_3y: 1.3}
+      ram-extended:
+        cost:
+          us-central1: {hour: 0.009, month: 4.93}
+`)
+	tests := []struct {
+		name        string
+		machineType string
+		want        Cost
+		wantErr     bool
+	}{
+		{
+			name:        "N1 custom machine type",
+			machineType: "custom-2-4096",
+			want:        Cost{Hour: 2*0.03 + 4*0.004, Month: 2*15.33 + 4*2.044, Month1Y: 2*13 + 4*1.7, Month3Y: 2*10 + 4*1.2},
+		},
+		{
+			name:        "N2 custom machine type with extended memory",
+			machineType: "n2-custom-2-20480-ext",
+			want: Cost{
+				Hour:    2*0.03 + 16*0.004 + 4*0.009,
+				Month:   2*16.06 + 16*2.19 + 4*4.93,
+				Month1Y: 2*14 + 16*1.8 + 4*0.009*HoursMonth,
+				Month3Y: 2*10 + 16*1.3 + 4*0.009*HoursMonth,
+			},
+		},
+		{
+			name:        "machine family without prices",
+			machineType: "e2-custom-2-4096",
+			wantErr:     true,
+		},
+		{
+			name:        "invalid limits of machine family",
+			machineType: "n2-custom-2-20480",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CostComputeCustomInstance(pricingYml, tt.machineType, "us-central1")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("cost = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got.Hour, tt.want.Hour) || !testEqual(got.Month, tt.want.Month) || !testEqual(got.Month1Y, tt.want.Month1Y) || !testEqual(got.Month3Y, tt.want.Month3Y) {
+				t.Errorf("cost = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
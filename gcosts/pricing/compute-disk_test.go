@@ -0,0 +1,138 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+const testDiskPricing = `
+compute:
+  storage:
+    local:
+      partition: 375
+      cost:
+        us-central1: {month: 0.08}
+    hyperdisk-balanced:
+      iops: {min: 3000, max: 160000, included: 3000, cost: {us-central1: {month: 0.005}}}
+      throughput: {min: 140, max: 2400, included: 140, cost: {us-central1: {month: 0.04}}}
+      cost:
+        us-central1: {month: 0.08}
+        europe-west4: {month: 0.09}
+    hyperdisk-throughput:
+      throughput: {min: 10, max: 600, cost: {us-central1: {month: 0.05}}}
+      cost:
+        us-central1: {month: 0.05}
+    ssd:
+      cost:
+        us-central1: {month: 0.17}
+`
+
+func TestCalcComputeDiskPartitions(t *testing.T) {
+	pricingYml := testPricing(t, testDiskPricing)
+	tests := []struct {
+		name       string
+		diskType   string
+		partitions int
+		want       float32
+		wantData   float32
+		wantErr    bool
+	}{
+		{name: "one partition", diskType: "local", partitions: 1, want: 375 * 0.08, wantData: 375},
+		{name: "eight partitions", diskType: "local", partitions: 8, want: 8 * 375 * 0.08, wantData: 3000},
+		{name: "disk type without partitions", diskType: "ssd", partitions: 1, wantErr: true},
+		{name: "unknown disk type", diskType: "nvme", partitions: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcComputeDiskPartitions("disk", tt.diskType, tt.partitions, "us-central1", 1)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+			if len(e.LineItems) != 1 || e.LineItems[0].Data != tt.wantData {
+				t.Errorf("line items = %+v, want one line item with %.0f GiB", e.LineItems, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestCalcComputeDiskPerformance(t *testing.T) {
+	pricingYml := testPricing(t, testDiskPricing)
+	tests := []struct {
+		name           string
+		diskType       string
+		iops           float32
+		throughput     float32
+		region         string
+		discount       float32
+		wantIops       float32 // Cost of the IOPS above the included IOPS
+		wantThroughput float32 // Cost of the throughput above the included throughput
+		wantErr        bool
+	}{
+		{name: "included performance", diskType: "hyperdisk-balanced", iops: 3000, throughput: 140},
+		{name: "below included performance", diskType: "hyperdisk-balanced", iops: 1000, throughput: 100},
+		{name: "IOPS above included", diskType: "hyperdisk-balanced", iops: 3001, wantIops: 0.005},
+		{name: "maximum IOPS", diskType: "hyperdisk-balanced", iops: 160000, wantIops: 157000 * 0.005},
+		{name: "throughput above included", diskType: "hyperdisk-balanced", throughput: 240, wantThroughput: 100 * 0.04},
+		{name: "IOPS and throughput", diskType: "hyperdisk-balanced", iops: 5000, throughput: 240, wantIops: 2000 * 0.005, wantThroughput: 100 * 0.04},
+		{name: "discount", diskType: "hyperdisk-balanced", iops: 5000, throughput: 240, discount: 0.5, wantIops: 2000 * 0.005 * 0.5, wantThroughput: 100 * 0.04 * 0.5},
+		{name: "nothing included", diskType: "hyperdisk-throughput", throughput: 10, wantThroughput: 10 * 0.05},
+		{name: "included performance without price in region", diskType: "hyperdisk-balanced", iops: 3000, throughput: 140, region: "europe-west4"},
+		{name: "no price of IOPS in region", diskType: "hyperdisk-balanced", iops: 3001, region: "europe-west4", wantErr: true},
+		{name: "no price of throughput in region", diskType: "hyperdisk-balanced", throughput: 141, region: "europe-west4", wantErr: true},
+		{name: "no provisioned performance", diskType: "ssd", iops: 1000, wantErr: true},
+		{name: "unknown disk type", diskType: "nvme", iops: 1000, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region := tt.region
+			if len(region) == 0 {
+				region = "us-central1"
+			}
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcComputeDiskPerformance("disk", tt.diskType, tt.iops, tt.throughput, region, tt.discount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := tt.wantIops + tt.wantThroughput; !testEqual(got, want) {
+				t.Errorf("price = %f, want %f", got, want)
+			}
+			costs := map[string]float32{}
+			for _, lineItem := range e.LineItems {
+				costs[lineItem.Resource] += lineItem.Cost
+			}
+			if !testEqual(costs["disk-iops"], tt.wantIops) || !testEqual(costs["disk-throughput"], tt.wantThroughput) {
+				t.Errorf("IOPS = %f, throughput = %f, want %f, %f", costs["disk-iops"], costs["disk-throughput"], tt.wantIops, tt.wantThroughput)
+			}
+		})
+	}
+}
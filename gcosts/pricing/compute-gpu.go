@@ -0,0 +1,179 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Google Compute Engine GPU (accelerator)
+
+// computeGpuAttachable are the GPUs that can be attached to N1 machine types.
+// All other GPUs are only available with accelerator-optimized machine types (A2, A3, G2, ...)
+// and are included in the price of the machine type.
+// https://cloud.google.com/compute/docs/gpus
+var computeGpuAttachable = []string{
+	"nvidia-tesla-k80",
+	"nvidia-tesla-p4",
+	"nvidia-tesla-p100",
+	"nvidia-tesla-t4",
+	"nvidia-tesla-v100",
+}
+
+func CheckComputeGpu(pricingYml StructPricing, inputGpuType string) (Gpu, error) {
+	gpus := pricingYml.Compute.Gpu
+	gpu, ok := gpus[inputGpuType]
+	if !ok {
+		return Gpu{}, &ResourceError{Resource: "Google Compute Engine GPU type", Name: inputGpuType}
+	}
+	return gpu, nil
+}
+
+// CheckComputeGpuRegion checks if the GPU is available in the region
+func CheckComputeGpuRegion(pricingYml StructPricing, inputGpuType string, inputRegion string) (Gpu, error) {
+	gpu, err := CheckComputeGpu(pricingYml, inputGpuType)
+	if err != nil {
+		return Gpu{}, err
+	}
+	// Pricing files without availability information
+	if len(gpu.Regions) == 0 {
+		return gpu, nil
+	}
+	if !slices.Contains(gpu.Regions, inputRegion) {
+		return Gpu{}, &ResourceError{Resource: "GCE GPU type", Name: inputGpuType, Region: inputRegion}
+	}
+	return gpu, nil
+}
+
+// CheckComputeGpuMachineType checks if the GPU can be attached to the machine type
+func CheckComputeGpuMachineType(inputGpuType string, inputMachineType string) error {
+	family, _, _ := strings.Cut(inputMachineType, "-")
+	// Custom machine types without prefix are N1 machine types
+	if (family != "n1" && family != "custom") || !slices.Contains(computeGpuAttachable, inputGpuType) {
+		return &ResourceError{Resource: fmt.Sprintf("GPU '%s' for GCE machine type", inputGpuType), Name: inputMachineType}
+	}
+	return nil
+}
+
+func CostComputeGpu(pricingYml StructPricing, inputGpuType string, inputRegion string) (Cost, error) {
+	gpu, err := CheckComputeGpuRegion(pricingYml, inputGpuType, inputRegion)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := gpu.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE GPU type", Name: inputGpuType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func (e *Estimate) returnComputeGpuCount(inputValue int) int {
+	outputValue := 1
+	if inputValue > 0 {
+		outputValue = inputValue
+	} else if inputValue < 0 {
+		e.warning("Invalid GCE GPU count: '%v'", inputValue)
+	}
+	e.info("GCE GPU count: %d", outputValue)
+	return outputValue
+}
+
+// CalcComputeGpu calculates the costs of the GPUs attached to the VM with the same runtime,
+// provisioning model and commitment as the VM
+func (e *Estimate) CalcComputeGpu(inputName string, inputGpuType string, inputCount int, inputMachineType string, inputRegion string, inputDiscount float32, inputCommitment int, inputSpot bool, inputTerminated bool, inputHours float32) (float32, error) {
+	name := e.returnComputeInstanceName("", inputName)
+	count := e.returnComputeGpuCount(inputCount)
+	commitment := e.returnComputeInstanceCommitment(inputCommitment)
+	spot := e.returnComputeInstanceSpot(inputSpot)
+	terminated := e.returnComputeInstanceTerminated(inputTerminated)
+	hours := e.returnComputeInstanceHours(inputHours)
+	if commitment > 0 {
+		hours = 0
+	}
+	if err := CheckComputeGpuMachineType(inputGpuType, inputMachineType); err != nil {
+		return 0, err
+	}
+	cost, err := CostComputeGpu(e.Pricing, inputGpuType, inputRegion)
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE GPU type '%s' in region '%s' found.", inputGpuType, inputRegion)
+	discount, discountText := returnDiscount(inputDiscount)
+
+	var price float32
+	var sud float32 // Sustained use discount (0 = not eligible)
+	var ok bool
+	if commitment == 1 {
+		price, err = e.month1Y(cost)
+		price = price * float32(count) * discount
+		e.info("1Y CUD price '%s' %dx GPU per month: $%.2f %s", name, count, price, discountText)
+	} else if commitment == 3 {
+		price, err = e.month3Y(cost)
+		price = price * float32(count) * discount
+		e.info("3Y CUD price '%s' %dx GPU per month: $%.2f %s", name, count, price, discountText)
+	} else if terminated {
+		price = 0
+		e.info("Price '%s' %dx GPU per month: $%.2f (terminated instance)", name, count, price)
+	} else if spot && hours > 0 {
+		price, err = e.hourSpot(cost)
+		price = price * hours * float32(count) * discount
+		e.info("Spot price '%s' %dx GPU %.2f hours per month: $%.2f %s", name, count, hours, price, discountText)
+	} else if spot {
+		price, err = e.monthSpot(cost)
+		price = price * float32(count) * discount
+		e.info("Spot price '%s' %dx GPU per month: $%.2f %s", name, count, price, discountText)
+	} else if hours > 0 {
+		// GPUs get the sustained use discount of the machine type
+		sud, ok = SustainedUseDiscount(inputMachineType, hours)
+		price, err = Hour(cost)
+		price = price * hours * sud * float32(count) * discount
+		e.info("Price '%s' %dx GPU %.2f hours per month: $%.2f %s", name, count, hours, price, discountText)
+	} else {
+		// Monthly price includes the sustained use discount
+		sud, ok = SustainedUseDiscount(inputMachineType, HoursMonth)
+		price, err = Month(cost)
+		price = price * float32(count) * discount
+		e.info("Price '%s' %dx GPU per month: $%.2f %s", name, count, price, discountText)
+	}
+	if ok {
+		e.info("Sustained use discount '%s': %.2f", name, sud)
+	} else {
+		sud = 0
+	}
+	if err != nil {
+		return 0, err
+	}
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:       e.File,
+			Project:    e.Project,
+			Name:       name,
+			Type:       inputGpuType,
+			Data:       float32(count),
+			Region:     inputRegion,
+			Resource:   "gpu",
+			Commitment: commitment,
+			Discount:   discount,
+			Sud:        sud,
+			Cost:       price,
+
+			billingAccount: e.BillingAccount,
+		})
+	}
+	return price, nil
+}
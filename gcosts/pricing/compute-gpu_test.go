@@ -0,0 +1,42 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+func TestCheckComputeGpuMachineType(t *testing.T) {
+	tests := []struct {
+		gpuType     string
+		machineType string
+		wantErr     bool
+	}{
+		{"nvidia-tesla-t4", "n1-standard-4", false},
+		{"nvidia-tesla-v100", "custom-8-30720", false},
+		{"nvidia-tesla-t4", "n2-standard-4", true},
+		{"nvidia-tesla-a100", "n1-standard-4", true},
+		{"nvidia-l4", "g2-standard-4", true},
+		{"nvidia-tesla-t4-vws", "n1-standard-4", true}, // No prices for NVIDIA RTX Virtual Workstations
+	}
+	for _, tt := range tests {
+		t.Run(tt.gpuType+"/"+tt.machineType, func(t *testing.T) {
+			if err := CheckComputeGpuMachineType(tt.gpuType, tt.machineType); (err != nil) != tt.wantErr {
+				t.Errorf("CheckComputeGpuMachineType(%s, %s) = %v, want error %t", tt.gpuType, tt.machineType, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -15,58 +15,67 @@ limitations under the License.
 */
 package pricing
 
-import (
-	"github.com/pterm/pterm"
-	"os"
-)
-
 // Google Compute Engine external public IP attached but unused
 
-func CostComputeNetworkIpUnused(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkIpUnused(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Ip.Unused.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCE external public unused IP in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("GCE external public unused IP in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE external public unused IP", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
 // Google Compute Engine external public IP attached and used on VM
 
-func CostComputeNetworkIpVm(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkIpVm(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Ip.Vm.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCE external public IP in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("GCE external public IP in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE external public IP", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CalcComputeNetworkIp(pricingYml StructPricing, inputName string, inputExternalIp int, inputRegion string, inputDiscount float32, inputTerminated bool) float32 {
-	name := returnComputeInstanceName("", inputName)
+// CalcComputeNetworkIp calculates the costs of the external IP addresses of the VM with the same runtime as the VM
+func (e *Estimate) CalcComputeNetworkIp(inputName string, inputExternalIp int, inputRegion string, inputDiscount float32, inputTerminated bool, inputHours float32) (float32, error) {
+	name := e.returnComputeInstanceName("", inputName)
 	// TODO: Calc spot price
-	//spot := returnComputeInstanceSpot(inputSpot)
-	terminated := returnComputeInstanceTerminated(inputTerminated)
+	//spot := e.returnComputeInstanceSpot(inputSpot)
+	terminated := e.returnComputeInstanceTerminated(inputTerminated)
+	hours := e.returnComputeInstanceHours(inputHours)
 	discount, discountText := returnDiscount(inputDiscount)
 	var externalIp = float32(inputExternalIp)
 	var price float32
 	if externalIp > 0 {
 		if terminated {
-			price = (Month(CostComputeNetworkIpUnused(pricingYml, inputRegion)) * externalIp) * discount
-			pterm.Info.Printf("Price '%s' %v unused IP per month: $%.2f (terminated instance) %s\n", name, inputExternalIp, price, discountText)
+			month, err := costMonth(CostComputeNetworkIpUnused(e.Pricing, inputRegion))
+			if err != nil {
+				return 0, err
+			}
+			e.success("GCE external public unused IP in region '%s' found.", inputRegion)
+			price = (month * externalIp) * discount
+			e.info("Price '%s' %v unused IP per month: $%.2f (terminated instance) %s", name, inputExternalIp, price, discountText)
+		} else if hours > 0 {
+			hour, err := costHour(CostComputeNetworkIpVm(e.Pricing, inputRegion))
+			if err != nil {
+				return 0, err
+			}
+			e.success("GCE external public IP in region '%s' found.", inputRegion)
+			price = (hour * hours * externalIp) * discount
+			e.info("Price '%s' %v IP %.2f hours per month: $%.2f %s", name, inputExternalIp, hours, price, discountText)
 		} else {
-			price = (Month(CostComputeNetworkIpVm(pricingYml, inputRegion)) * externalIp) * discount
-			pterm.Info.Printf("Price '%s' %v IP per month: $%.2f %s\n", name, inputExternalIp, price, discountText)
+			month, err := costMonth(CostComputeNetworkIpVm(e.Pricing, inputRegion))
+			if err != nil {
+				return 0, err
+			}
+			e.success("GCE external public IP in region '%s' found.", inputRegion)
+			price = (month * externalIp) * discount
+			e.info("Price '%s' %v IP per month: $%.2f %s", name, inputExternalIp, price, discountText)
 		}
 	}
 	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:     File,
-			Project:  Project,
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
 			Region:   inputRegion,
 			Name:     name,
 			Type:     "ip",
@@ -76,36 +85,30 @@ func CalcComputeNetworkIp(pricingYml StructPricing, inputName string, inputExter
 			Cost:     price,
 		})
 	}
-	return price
+	return price, nil
 }
 
 // Google Compute Engine network NAT ingress and egress data
 
-func CostComputeNetworkNatData(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkNatData(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Nat.Data.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCE network NAT data in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("GCE network NAT data in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network NAT data", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
 // Google Compute Engine network NAT gateway
 
-func CostComputeNetworkNatGateway(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkNatGateway(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Nat.Gateway.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCE network NAT gateway in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("GCE network NAT gateway in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network NAT gateway", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func returnComputeNetworkNatGatewayName(defaultName string, inputName string) string {
+func (e *Estimate) returnComputeNetworkNatGatewayName(defaultName string, inputName string) string {
 	var name string
 	if len(defaultName) > 0 {
 		name = defaultName
@@ -115,28 +118,36 @@ func returnComputeNetworkNatGatewayName(defaultName string, inputName string) st
 	if len(inputName) > 0 {
 		name = inputName
 	}
-	pterm.Info.Printf("GCE network NAT gateway name: '%s'\n", name)
+	e.info("GCE network NAT gateway name: '%s'", name)
 	return name
 }
 
-func CalcComputeNetworkNatGateway(pricingYml StructPricing, inputName string, inputData float32, inputRegion string, inputDiscount float32) float32 {
-	name := returnComputeNetworkNatGatewayName("", inputName)
+func (e *Estimate) CalcComputeNetworkNatGateway(inputName string, inputData float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeNetworkNatGatewayName("", inputName)
 	discount, discountText := returnDiscount(inputDiscount)
 	// Gateway
-	costComputeNetworkNatGateway := CostComputeNetworkNatGateway(pricingYml, inputRegion)
-	priceComputeNetworkNatGateway := Month(costComputeNetworkNatGateway) * discount
-	pterm.Info.Printf("Price '%s' NAT gateway per month: $%.2f %s\n", name, priceComputeNetworkNatGateway, discountText)
+	monthComputeNetworkNatGateway, err := costMonth(CostComputeNetworkNatGateway(e.Pricing, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE network NAT gateway in region '%s' found.", inputRegion)
+	priceComputeNetworkNatGateway := monthComputeNetworkNatGateway * discount
+	e.info("Price '%s' NAT gateway per month: $%.2f %s", name, priceComputeNetworkNatGateway, discountText)
 	// Data
-	costComputeNetworkNatData := CostComputeNetworkNatData(pricingYml, inputRegion)
-	priceComputeNetworkNatData := (Month(costComputeNetworkNatData) * inputData) * discount
-	pterm.Info.Printf("Price '%s' %.2f MiB NAT data per month: $%.2f %s\n", name, inputData, priceComputeNetworkNatData, discountText)
+	monthComputeNetworkNatData, err := costMonth(CostComputeNetworkNatData(e.Pricing, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE network NAT data in region '%s' found.", inputRegion)
+	priceComputeNetworkNatData := (monthComputeNetworkNatData * inputData) * discount
+	e.info("Price '%s' %.2f MiB NAT data per month: $%.2f %s", name, inputData, priceComputeNetworkNatData, discountText)
 	// Sum
 	price := priceComputeNetworkNatGateway + priceComputeNetworkNatData
-	pterm.Info.Printf("Price '%s' NAT total per month: $%.2f %s\n", name, price, discountText)
+	e.info("Price '%s' NAT total per month: $%.2f %s", name, price, discountText)
 	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:     File,
-			Project:  Project,
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
 			Region:   inputRegion,
 			Name:     name,
 			Type:     "nat-gateway",
@@ -146,23 +157,20 @@ func CalcComputeNetworkNatGateway(pricingYml StructPricing, inputName string, in
 			Cost:     price,
 		})
 	}
-	return price
+	return price, nil
 }
 
 // Google Compute Engine network VPN tunnel
 
-func CostComputeNetworkVpnTunnel(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkVpnTunnel(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Vpn.Tunnel.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCE network VPN tunnel in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("GCE network VPN tunnel in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network VPN tunnel", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func returnComputeNetworkVpnTunnelName(defaultName string, inputName string) string {
+func (e *Estimate) returnComputeNetworkVpnTunnelName(defaultName string, inputName string) string {
 	var name string
 	if len(defaultName) > 0 {
 		name = defaultName
@@ -172,20 +180,24 @@ func returnComputeNetworkVpnTunnelName(defaultName string, inputName string) str
 	if len(inputName) > 0 {
 		name = inputName
 	}
-	pterm.Info.Printf("GCE network VPN tunnel name: '%s'\n", name)
+	e.info("GCE network VPN tunnel name: '%s'", name)
 	return name
 }
 
-func CalcComputeNetworkVpnTunnel(pricingYml StructPricing, inputName string, inputRegion string, inputDiscount float32) float32 {
-	name := returnComputeNetworkVpnTunnelName("", inputName)
-	cost := CostComputeNetworkVpnTunnel(pricingYml, inputRegion)
+func (e *Estimate) CalcComputeNetworkVpnTunnel(inputName string, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeNetworkVpnTunnelName("", inputName)
+	month, err := costMonth(CostComputeNetworkVpnTunnel(e.Pricing, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE network VPN tunnel in region '%s' found.", inputRegion)
 	discount, discountText := returnDiscount(inputDiscount)
-	price := Month(cost) * discount
-	pterm.Info.Printf("Price '%s' tunnel per month: $%.2f %s\n", name, price, discountText)
+	price := month * discount
+	e.info("Price '%s' tunnel per month: $%.2f %s", name, price, discountText)
 	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:     File,
-			Project:  Project,
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
 			Region:   inputRegion,
 			Name:     name,
 			Type:     "vpn-tunnel",
@@ -194,115 +206,122 @@ func CalcComputeNetworkVpnTunnel(pricingYml StructPricing, inputName string, inp
 			Cost:     price,
 		})
 	}
-	return price
+	return price, nil
 }
 
 // Google Cloud internet egress traffic
 
-func CostComputeNetworkTrafficEgressTiB0_1(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressTiB0_1(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.Cost.TiB0_1[inputRegion]
-	if ok {
-		pterm.Success.Printf("Google Cloud internet egress traffic (0-1 TiB) in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Google Cloud internet egress traffic (0-1 TiB) in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Google Cloud internet egress traffic (0-1 TiB)", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostComputeNetworkTrafficEgressTiB1_10(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressTiB1_10(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.Cost.TiB1_10[inputRegion]
-	if ok {
-		pterm.Success.Printf("Google Cloud internet egress traffic (1-10 TiB) in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Google Cloud internet egress traffic (1-10 TiB) in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Google Cloud internet egress traffic (1-10 TiB)", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostComputeNetworkTrafficEgressTiB10n(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressTiB10n(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.Cost.TiB10n[inputRegion]
-	if ok {
-		pterm.Success.Printf("Google Cloud internet egress traffic (10n TiB) in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Google Cloud internet egress traffic (10n TiB) in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Google Cloud internet egress traffic (10n TiB)", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
 // Google Cloud internet egress traffic with China destinations
 
-func CostComputeNetworkTrafficEgressChinaTiB0_1(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressChinaTiB0_1(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.China.Cost.TiB0_1[inputRegion]
-	if ok {
-		pterm.Success.Printf("Internet egress traffic (0-1 TiB) with China destinations in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Internet egress traffic (0-1 TiB) with China destinations in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Internet egress traffic (0-1 TiB) with China destinations", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostComputeNetworkTrafficEgressChinaTiB1_10(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressChinaTiB1_10(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.China.Cost.TiB1_10[inputRegion]
-	if ok {
-		pterm.Success.Printf("Internet egress traffic (1-10 TiB) with China destinations in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Internet egress traffic (1-10 TiB) with China destinations in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Internet egress traffic (1-10 TiB) with China destinations", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostComputeNetworkTrafficEgressChinaTiB10n(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressChinaTiB10n(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.China.Cost.TiB10n[inputRegion]
-	if ok {
-		pterm.Success.Printf("Internet egress traffic (10n TiB) with China destinations in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Internet egress traffic (10n TiB) with China destinations in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Internet egress traffic (10n TiB) with China destinations", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
 // Google Cloud internet egress traffic with Australia destinations
 
-func CostComputeNetworkTrafficEgressAustraliaTiB0_1(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressAustraliaTiB0_1(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.Australia.Cost.TiB0_1[inputRegion]
-	if ok {
-		pterm.Success.Printf("Internet egress traffic (0-1 TiB) with Australia destinations in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Internet egress traffic (0-1 TiB) with Australia destinations in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Internet egress traffic (0-1 TiB) with Australia destinations", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostComputeNetworkTrafficEgressAustraliaTiB1_10(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressAustraliaTiB1_10(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.Australia.Cost.TiB1_10[inputRegion]
-	if ok {
-		pterm.Success.Printf("Internet egress traffic (1-10 TiB) with Australia destinations in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Internet egress traffic (1-10 TiB) with Australia destinations in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Internet egress traffic (1-10 TiB) with Australia destinations", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostComputeNetworkTrafficEgressAustraliaTiB10n(pricingYml StructPricing, inputRegion string) Cost {
+func CostComputeNetworkTrafficEgressAustraliaTiB10n(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Compute.Network.Traffic.Egress.Internet.Australia.Cost.TiB10n[inputRegion]
-	if ok {
-		pterm.Success.Printf("Internet egress traffic (10n TiB) with Australia destinations in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Internet egress traffic (10n TiB) with Australia destinations in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Internet egress traffic (10n TiB) with Australia destinations", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func returnComputeNetworkTrafficEgressName(defaultName string, inputName string) string {
+// Tiers of internet egress traffic (GiB)
+
+var trafficEgressStarts = []float32{0, 1024, 10240} // 0-1 TiB, 1-10 TiB, 10n TiB
+
+func CostComputeNetworkTrafficEgressTiers(pricingYml StructPricing, inputRegion string) ([]Tier, error) {
+	return returnTiers(pricingYml.Compute.Network.Traffic.Egress.Internet.Tiers, inputRegion, func() ([]Tier, error) {
+		return returnRangeTiers(pricingYml, inputRegion, trafficEgressStarts,
+			CostComputeNetworkTrafficEgressTiB0_1,
+			CostComputeNetworkTrafficEgressTiB1_10,
+			CostComputeNetworkTrafficEgressTiB10n,
+		)
+	})
+}
+
+func CostComputeNetworkTrafficEgressChinaTiers(pricingYml StructPricing, inputRegion string) ([]Tier, error) {
+	return returnTiers(pricingYml.Compute.Network.Traffic.Egress.Internet.China.Tiers, inputRegion, func() ([]Tier, error) {
+		return returnRangeTiers(pricingYml, inputRegion, trafficEgressStarts,
+			CostComputeNetworkTrafficEgressChinaTiB0_1,
+			CostComputeNetworkTrafficEgressChinaTiB1_10,
+			CostComputeNetworkTrafficEgressChinaTiB10n,
+		)
+	})
+}
+
+func CostComputeNetworkTrafficEgressAustraliaTiers(pricingYml StructPricing, inputRegion string) ([]Tier, error) {
+	return returnTiers(pricingYml.Compute.Network.Traffic.Egress.Internet.Australia.Tiers, inputRegion, func() ([]Tier, error) {
+		return returnRangeTiers(pricingYml, inputRegion, trafficEgressStarts,
+			CostComputeNetworkTrafficEgressAustraliaTiB0_1,
+			CostComputeNetworkTrafficEgressAustraliaTiB1_10,
+			CostComputeNetworkTrafficEgressAustraliaTiB10n,
+		)
+	})
+}
+
+func (e *Estimate) returnComputeNetworkTrafficEgressName(defaultName string, inputName string) string {
 	var name string
 	if len(defaultName) > 0 {
 		name = defaultName
@@ -312,124 +331,286 @@ func returnComputeNetworkTrafficEgressName(defaultName string, inputName string)
 	if len(inputName) > 0 {
 		name = inputName
 	}
-	pterm.Info.Printf("Internet egress traffic name: '%s'\n", name)
+	e.info("Internet egress traffic name: '%s'", name)
 	return name
 }
 
-func CalcComputeNetworkTrafficEgress(pricingYml StructPricing, inputName string, inputWorld float32, inputChina float32, inputAustralia float32, inputRegion string, inputDiscount float32) float32 {
-	name := returnComputeNetworkTrafficEgressName("", inputName)
+func (e *Estimate) CalcComputeNetworkTrafficEgress(inputName string, inputWorld float32, inputChina float32, inputAustralia float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeNetworkTrafficEgressName("", inputName)
 	discount, discountText := returnDiscount(inputDiscount)
-	var range1 float32 = 1024  // 0-1 TiB
-	var range2 float32 = 10240 // 1-10 TiB
+	destinations := []struct {
+		data  float32
+		tiers func(StructPricing, string) ([]Tier, error)
+		kind  string // Type of line item
+		text  string
+	}{
+		{inputWorld, CostComputeNetworkTrafficEgressTiers, "traffic", "traffic"},
+		{inputChina, CostComputeNetworkTrafficEgressChinaTiers, "traffic-cn", "traffic w. CN dest."},
+		{inputAustralia, CostComputeNetworkTrafficEgressAustraliaTiers, "traffic-au", "traffic w. AU dest."},
+	}
 	var price float32
-	if inputWorld > 0 {
-		// 0-1 TiB
-		month1 := Month(CostComputeNetworkTrafficEgressTiB0_1(pricingYml, inputRegion))
-		monthRange1 := range1 * month1
-		// 1-10 TiB
-		month2 := Month(CostComputeNetworkTrafficEgressTiB1_10(pricingYml, inputRegion))
-		monthRange2 := (range2 - range1) * month2
-		// 10n TiB
-		month3 := Month(CostComputeNetworkTrafficEgressTiB10n(pricingYml, inputRegion))
-		var priceTraffic float32
-		if inputWorld > range2 {
-			priceTraffic = (inputWorld - range2) * month3
-			priceTraffic = priceTraffic + monthRange2 + monthRange1
-		} else if inputWorld > range1 {
-			priceTraffic = (inputWorld - range1) * month2
-			priceTraffic = priceTraffic + monthRange1
-		} else {
-			priceTraffic = inputWorld * month1
+	for _, destination := range destinations {
+		if !(destination.data > 0) {
+			continue
 		}
-		priceTraffic = priceTraffic * discount
-		pterm.Info.Printf("Price '%s' %.2f GiB traffic per month: $%.2f %s\n", name, inputWorld, priceTraffic, discountText)
-		if priceTraffic > 0 {
-			LineItems = append(LineItems, LineItem{
-				File:     File,
-				Project:  Project,
-				Region:   inputRegion,
-				Name:     name,
-				Data:     inputWorld,
-				Type:     "traffic",
-				Resource: "network",
-				Discount: discount,
-				Cost:     priceTraffic,
-			})
+		tiers, err := destination.tiers(e.Pricing, inputRegion)
+		if err != nil {
+			return 0, err
 		}
-		price = price + priceTraffic
-	}
-	if inputChina > 0 {
-		// 0-1 TiB
-		month1 := Month(CostComputeNetworkTrafficEgressChinaTiB0_1(pricingYml, inputRegion))
-		monthRange1 := range1 * month1
-		// 1-10 TiB
-		month2 := Month(CostComputeNetworkTrafficEgressChinaTiB1_10(pricingYml, inputRegion))
-		monthRange2 := (range2 - range1) * month2
-		// 10n TiB
-		month3 := Month(CostComputeNetworkTrafficEgressChinaTiB10n(pricingYml, inputRegion))
-		var priceTraffic float32
-		if inputChina > range2 {
-			priceTraffic = (inputChina - range2) * month3
-			priceTraffic = priceTraffic + monthRange2 + monthRange1
-		} else if inputWorld > range1 {
-			priceTraffic = (inputChina - range1) * month2
-			priceTraffic = priceTraffic + monthRange1
-		} else {
-			priceTraffic = inputChina * month1
-		}
-		priceTraffic = priceTraffic * discount
-		pterm.Info.Printf("Price '%s' %.2f GiB traffic w. CN dest. per month: $%.2f %s\n", name, inputChina, priceTraffic, discountText)
+		priceTraffic := TieredPrice(tiers, destination.data) * discount
+		e.info("Price '%s' %.2f GiB %s per month: $%.2f %s", name, destination.data, destination.text, priceTraffic, discountText)
 		if priceTraffic > 0 {
-			LineItems = append(LineItems, LineItem{
-				File:     File,
-				Project:  Project,
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
 				Region:   inputRegion,
 				Name:     name,
-				Data:     inputChina,
-				Type:     "traffic-cn",
+				Data:     destination.data,
+				Type:     destination.kind,
 				Resource: "network",
 				Discount: discount,
 				Cost:     priceTraffic,
+
+				tiers:          tiers,
+				billingAccount: e.BillingAccount,
 			})
 		}
 		price = price + priceTraffic
 	}
-	if inputAustralia > 0 {
-		// 0-1 TiB
-		month1 := Month(CostComputeNetworkTrafficEgressAustraliaTiB0_1(pricingYml, inputRegion))
-		monthRange1 := range1 * month1
-		// 1-10 TiB
-		month2 := Month(CostComputeNetworkTrafficEgressAustraliaTiB1_10(pricingYml, inputRegion))
-		monthRange2 := (range2 - range1) * month2
-		// 10n TiB
-		month3 := Month(CostComputeNetworkTrafficEgressAustraliaTiB10n(pricingYml, inputRegion))
-		var priceTraffic float32
-		if inputAustralia > range2 {
-			priceTraffic = (inputAustralia - range2) * month3
-			priceTraffic = priceTraffic + monthRange2 + monthRange1
-		} else if inputWorld > range1 {
-			priceTraffic = (inputAustralia - range1) * month2
-			priceTraffic = priceTraffic + monthRange1
-		} else {
-			priceTraffic = inputAustralia * month1
+	e.info("Price '%s' total internet egress traffic per month: $%.2f %s", name, price, discountText)
+	return price, nil
+}
+
+// Google Compute Engine network load balancer (Cloud Load Balancing)
+
+// LoadBalancerRulesIncluded is the number of forwarding rules included in the minimum service charge
+const LoadBalancerRulesIncluded = 5
+
+func CheckComputeNetworkLb(pricingYml StructPricing, inputLbType string) (LoadBalancer, error) {
+	lb, ok := pricingYml.Compute.Network.Lb[inputLbType]
+	if !ok {
+		return LoadBalancer{}, &ResourceError{Resource: "GCE network load balancer type", Name: inputLbType}
+	}
+	return lb, nil
+}
+
+func CostComputeNetworkLbRule(pricingYml StructPricing, inputLbType string, inputRegion string) (Cost, error) {
+	lb, err := CheckComputeNetworkLb(pricingYml, inputLbType)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := lb.Rule.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network load balancer forwarding rule", Name: inputLbType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostComputeNetworkLbRuleAdditional(pricingYml StructPricing, inputLbType string, inputRegion string) (Cost, error) {
+	lb, err := CheckComputeNetworkLb(pricingYml, inputLbType)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := lb.RuleAdditional.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network load balancer additional forwarding rule", Name: inputLbType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostComputeNetworkLbData(pricingYml StructPricing, inputLbType string, inputRegion string) (Cost, error) {
+	lb, err := CheckComputeNetworkLb(pricingYml, inputLbType)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := lb.Data.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network load balancer data", Name: inputLbType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func (e *Estimate) returnComputeNetworkLbName(defaultName string, inputName string) string {
+	var name string
+	if len(defaultName) > 0 {
+		name = defaultName
+	} else {
+		name = "default-load-balancer"
+	}
+	if len(inputName) > 0 {
+		name = inputName
+	}
+	e.info("GCE network load balancer name: '%s'", name)
+	return name
+}
+
+func (e *Estimate) returnComputeNetworkLbType(inputValue string) string {
+	outputValue := "external"
+	if len(inputValue) > 0 {
+		outputValue = inputValue
+	}
+	e.info("GCE network load balancer type: '%s'", outputValue)
+	return outputValue
+}
+
+func (e *Estimate) returnComputeNetworkLbRules(inputValue int) int {
+	outputValue := 1
+	if inputValue > 0 {
+		outputValue = inputValue
+	} else if inputValue < 0 {
+		e.warning("Invalid GCE network load balancer forwarding rules: '%v'", inputValue)
+	}
+	e.info("GCE network load balancer forwarding rules: %d", outputValue)
+	return outputValue
+}
+
+// CalcComputeNetworkLb calculates the costs of the load balancer.
+// The minimum service charge includes the first five forwarding rules,
+// each additional forwarding rule and the processed data (GiB) are charged on top.
+func (e *Estimate) CalcComputeNetworkLb(inputName string, inputLbType string, inputRules int, inputData float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeNetworkLbName("", inputName)
+	lbType := e.returnComputeNetworkLbType(inputLbType)
+	rules := e.returnComputeNetworkLbRules(inputRules)
+	discount, discountText := returnDiscount(inputDiscount)
+	// Minimum service charge
+	monthComputeNetworkLbRule, err := costMonth(CostComputeNetworkLbRule(e.Pricing, lbType, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE network load balancer type '%s' in region '%s' found.", lbType, inputRegion)
+	priceComputeNetworkLbRule := monthComputeNetworkLbRule * discount
+	e.info("Price '%s' first %d forwarding rules (minimum) per month: $%.2f %s", name, LoadBalancerRulesIncluded, priceComputeNetworkLbRule, discountText)
+	// Additional forwarding rules
+	var priceComputeNetworkLbRuleAdditional float32
+	if rules > LoadBalancerRulesIncluded {
+		monthComputeNetworkLbRuleAdditional, err := costMonth(CostComputeNetworkLbRuleAdditional(e.Pricing, lbType, inputRegion))
+		if err != nil {
+			return 0, err
 		}
-		priceTraffic = priceTraffic * discount
-		pterm.Info.Printf("Price '%s' %.2f GiB traffic w. AU dest. per month: $%.2f %s\n", name, inputAustralia, priceTraffic, discountText)
-		if priceTraffic > 0 {
-			LineItems = append(LineItems, LineItem{
-				File:     File,
-				Project:  Project,
-				Region:   inputRegion,
-				Name:     name,
-				Data:     inputAustralia,
-				Type:     "traffic-au",
-				Resource: "network",
-				Discount: discount,
-				Cost:     priceTraffic,
-			})
+		priceComputeNetworkLbRuleAdditional = (monthComputeNetworkLbRuleAdditional * float32(rules-LoadBalancerRulesIncluded)) * discount
+		e.info("Price '%s' %d additional forwarding rules per month: $%.2f %s", name, rules-LoadBalancerRulesIncluded, priceComputeNetworkLbRuleAdditional, discountText)
+	}
+	// Data
+	var priceComputeNetworkLbData float32
+	if inputData > 0 {
+		monthComputeNetworkLbData, err := costMonth(CostComputeNetworkLbData(e.Pricing, lbType, inputRegion))
+		if err != nil {
+			return 0, err
 		}
-		price = price + priceTraffic
+		priceComputeNetworkLbData = (monthComputeNetworkLbData * inputData) * discount
+		e.info("Price '%s' %.2f GiB processed data per month: $%.2f %s", name, inputData, priceComputeNetworkLbData, discountText)
+	}
+	// Sum
+	price := priceComputeNetworkLbRule + priceComputeNetworkLbRuleAdditional + priceComputeNetworkLbData
+	e.info("Price '%s' load balancer total per month: $%.2f %s", name, price, discountText)
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Region:   inputRegion,
+			Name:     name,
+			Type:     "lb-" + lbType,
+			Data:     inputData,
+			Resource: "network",
+			Discount: discount,
+			Cost:     price,
+		})
+	}
+	return price, nil
+}
+
+// Google Compute Engine network inter-zone and inter-region egress traffic
+
+func CostComputeNetworkTrafficInterZone(pricingYml StructPricing, inputRegion string) (Cost, error) {
+	cost, ok := pricingYml.Compute.Network.Traffic.Egress.InterZone.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network inter-zone egress traffic", Region: inputRegion}
+	}
+	return cost, nil
+}
+
+// ReturnContinent returns the continent of the region
+func ReturnContinent(pricingYml StructPricing, inputRegion string) (string, error) {
+	region, ok := pricingYml.Region[inputRegion]
+	if !ok {
+		return "", &RegionError{Region: inputRegion}
+	}
+	if len(region.Continent) == 0 {
+		return "", &ResourceError{Resource: "Continent of Google Cloud region", Name: inputRegion}
+	}
+	return region.Continent, nil
+}
+
+func CostComputeNetworkTrafficInterRegion(pricingYml StructPricing, inputSourceRegion string, inputDestinationRegion string) (Cost, error) {
+	source, err := ReturnContinent(pricingYml, inputSourceRegion)
+	if err != nil {
+		return Cost{}, err
+	}
+	destination, err := ReturnContinent(pricingYml, inputDestinationRegion)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := pricingYml.Compute.Network.Traffic.Egress.InterRegion.Cost[source][destination]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE network inter-region egress traffic to continent", Name: destination, Region: inputSourceRegion}
+	}
+	return cost, nil
+}
+
+// CalcComputeNetworkTrafficInterZone calculates the costs of the traffic between zones in the same region
+func (e *Estimate) CalcComputeNetworkTrafficInterZone(inputName string, inputData float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeNetworkTrafficEgressName("", inputName)
+	discount, discountText := returnDiscount(inputDiscount)
+	month, err := costMonth(CostComputeNetworkTrafficInterZone(e.Pricing, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE network inter-zone egress traffic in region '%s' found.", inputRegion)
+	price := (month * inputData) * discount
+	e.info("Price '%s' %.2f GiB inter-zone traffic per month: $%.2f %s", name, inputData, price, discountText)
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Region:   inputRegion,
+			Name:     name,
+			Data:     inputData,
+			Type:     "traffic-inter-zone",
+			Resource: "network",
+			Discount: discount,
+			Cost:     price,
+		})
+	}
+	return price, nil
+}
+
+// CalcComputeNetworkTrafficInterRegion calculates the costs of the traffic from the source region to the destination region
+func (e *Estimate) CalcComputeNetworkTrafficInterRegion(inputName string, inputData float32, inputSourceRegion string, inputDestinationRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeNetworkTrafficEgressName("", inputName)
+	discount, discountText := returnDiscount(inputDiscount)
+	if inputSourceRegion == inputDestinationRegion {
+		e.warning("Inter-region traffic '%s' from '%s' to the same region. Use inter-zone traffic.", name, inputSourceRegion)
+		return 0, nil
+	}
+	month, err := costMonth(CostComputeNetworkTrafficInterRegion(e.Pricing, inputSourceRegion, inputDestinationRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE network inter-region egress traffic from '%s' to '%s' found.", inputSourceRegion, inputDestinationRegion)
+	price := (month * inputData) * discount
+	e.info("Price '%s' %.2f GiB inter-region traffic to '%s' per month: $%.2f %s", name, inputData, inputDestinationRegion, price, discountText)
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Region:   inputSourceRegion,
+			Name:     name,
+			Data:     inputData,
+			Type:     "traffic-inter-region",
+			Resource: "network",
+			Discount: discount,
+			Cost:     price,
+		})
 	}
-	pterm.Info.Printf("Price '%s' total internet egress traffic per month: $%.2f %s\n", name, price, discountText)
-	return price
+	return price, nil
 }
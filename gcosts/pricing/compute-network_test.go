@@ -0,0 +1,196 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+func TestCalcComputeNetworkLb(t *testing.T) {
+	pricingYml := testPricing(t, `
+compute:
+  network:
+    lb:
+      external:
+        rule:
+          cost:
+            us-central1: {hour: 0.025, month: 18.25}
+        rule-additional:
+          cost:
+            us-central1: {hour: 0.01, month: 7.3}
+        data:
+          cost:
+            us-central1: {month: 0.008}
+      internal:
+        rule:
+          cost:
+            us-central1: {hour: 0.025, month: 18.25}
+`)
+	tests := []struct {
+		name     string
+		lbType   string
+		rules    int
+		data     float32
+		region   string
+		discount float32
+		want     float32
+		wantErr  bool
+	}{
+		{name: "default one rule", want: 18.25},
+		{name: "one rule", rules: 1, want: 18.25},
+		{name: "five rules in the minimum charge", rules: 5, want: 18.25},
+		{name: "six rules", rules: 6, want: 18.25 + 7.3},
+		{name: "ten rules", rules: 10, want: 18.25 + 5*7.3},
+		{name: "processed data", rules: 1, data: 1000, want: 18.25 + 1000*0.008},
+		{name: "six rules and processed data", rules: 6, data: 1000, want: 18.25 + 7.3 + 1000*0.008},
+		{name: "discount", rules: 6, data: 1000, discount: 0.5, want: (18.25 + 7.3 + 1000*0.008) * 0.5},
+		{name: "internal type", lbType: "internal", want: 18.25},
+		{name: "no price of additional rules", lbType: "internal", rules: 6, wantErr: true},
+		{name: "no price of processed data", lbType: "internal", data: 1, wantErr: true},
+		{name: "unknown type", lbType: "global", wantErr: true},
+		{name: "unknown region", region: "europe-west4", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region := tt.region
+			if len(region) == 0 {
+				region = "us-central1"
+			}
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcComputeNetworkLb("lb", tt.lbType, tt.rules, tt.data, region, tt.discount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+			if len(e.LineItems) != 1 || !testEqual(e.LineItems[0].Cost, tt.want) || e.LineItems[0].Data != tt.data {
+				t.Errorf("line items = %+v, want one line item with cost %f", e.LineItems, tt.want)
+			}
+		})
+	}
+}
+
+const testTrafficPricing = `
+region:
+  us-central1: {continent: north-america}
+  us-east1: {continent: north-america}
+  europe-west4: {continent: europe}
+  asia-east1: {continent: asia}
+  me-central2: {}
+compute:
+  network:
+    traffic:
+      egress:
+        inter-zone:
+          cost:
+            us-central1: {month: 0.01}
+        inter-region:
+          cost:
+            north-america:
+              north-america: {month: 0.02}
+              europe: {month: 0.05}
+            europe:
+              north-america: {month: 0.05}
+`
+
+func TestCalcComputeNetworkTrafficInterZone(t *testing.T) {
+	pricingYml := testPricing(t, testTrafficPricing)
+	tests := []struct {
+		name     string
+		data     float32
+		region   string
+		discount float32
+		want     float32
+		wantErr  bool
+	}{
+		{name: "traffic between zones", data: 1000, region: "us-central1", want: 10},
+		{name: "discount", data: 1000, region: "us-central1", discount: 0.5, want: 5},
+		{name: "no traffic", region: "us-central1", want: 0},
+		{name: "no price in region", data: 1000, region: "europe-west4", wantErr: true},
+		{name: "unknown region", data: 1000, region: "mars-north1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcComputeNetworkTrafficInterZone("traffic", tt.data, tt.region, tt.discount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+			if tt.want > 0 && (len(e.LineItems) != 1 || e.LineItems[0].Type != "traffic-inter-zone") {
+				t.Errorf("line items = %+v, want one inter-zone traffic line item", e.LineItems)
+			}
+		})
+	}
+}
+
+func TestCalcComputeNetworkTrafficInterRegion(t *testing.T) {
+	pricingYml := testPricing(t, testTrafficPricing)
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		discount    float32
+		want        float32 // 1000 GiB
+		wantErr     bool
+	}{
+		{name: "same continent", source: "us-central1", destination: "us-east1", want: 20},
+		{name: "different continents", source: "us-central1", destination: "europe-west4", want: 50},
+		{name: "other direction", source: "europe-west4", destination: "us-central1", want: 50},
+		{name: "discount", source: "us-central1", destination: "europe-west4", discount: 0.5, want: 25},
+		{name: "same region", source: "us-central1", destination: "us-central1", want: 0},
+		{name: "no price to continent", source: "europe-west4", destination: "asia-east1", wantErr: true},
+		{name: "unknown source region", source: "mars-north1", destination: "us-central1", wantErr: true},
+		{name: "unknown destination region", source: "us-central1", destination: "mars-north1", wantErr: true},
+		{name: "region without continent", source: "us-central1", destination: "me-central2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcComputeNetworkTrafficInterRegion("traffic", 1000, tt.source, tt.destination, tt.discount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+			if tt.want > 0 && (len(e.LineItems) != 1 || e.LineItems[0].Region != tt.source || e.LineItems[0].Type != "traffic-inter-region") {
+				t.Errorf("line items = %+v, want one inter-region traffic line item in the source region", e.LineItems)
+			}
+		})
+	}
+}
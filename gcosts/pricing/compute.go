@@ -16,75 +16,96 @@ limitations under the License.
 package pricing
 
 import (
-	"os"
-
-	"github.com/pterm/pterm"
+	"fmt"
 )
 
 // Google Compute Engine instance
 
-func CheckComputeInstance(pricingYml StructPricing, inputMachineType string) Instance {
+func CheckComputeInstance(pricingYml StructPricing, inputMachineType string) (Instance, error) {
 	instances := pricingYml.Compute.Instance
 	instance, ok := instances[inputMachineType]
-	if ok {
-		pterm.Success.Printf("Google Compute Engine machine type '%s' found.\n", inputMachineType)
-	} else {
-		pterm.Error.Printf("Google Compute Engine machine type '%s' not found!\n", inputMachineType)
-		os.Exit(1)
+	if !ok && IsCustomMachineType(inputMachineType) {
+		custom, err := CheckComputeCustomInstance(inputMachineType)
+		if err != nil {
+			return Instance{}, err
+		}
+		return Instance{Cpu: float32(custom.Cpu), Ram: custom.Ram + custom.RamExtended}, nil
+	} else if !ok {
+		return Instance{}, &ResourceError{Resource: "Google Compute Engine machine type", Name: inputMachineType}
 	}
-	return instance
+	return instance, nil
 }
 
-func CostComputeInstance(pricingYml StructPricing, inputMachineType string, inputRegion string) Cost {
-	instance := CheckComputeInstance(pricingYml, inputMachineType)
+func CostComputeInstance(pricingYml StructPricing, inputMachineType string, inputRegion string) (Cost, error) {
+	instance, err := CheckComputeInstance(pricingYml, inputMachineType)
+	if err != nil {
+		return Cost{}, err
+	}
+	// Custom machine types that are not in the pricing information
+	if instance.Cost == nil && IsCustomMachineType(inputMachineType) {
+		return CostComputeCustomInstance(pricingYml, inputMachineType, inputRegion)
+	}
 	cost, ok := instance.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCE machine type '%s' in region '%s' found.\n", inputMachineType, inputRegion)
-	} else {
-		pterm.Error.Printf("GCE machine type '%s' in region '%s' not found!\n", inputMachineType, inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE machine type", Name: inputMachineType, Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func returnComputeInstanceSpot(inputValue bool) bool {
+func (e *Estimate) returnComputeInstanceSpot(inputValue bool) bool {
 	var outputValue bool
 	if inputValue {
 		outputValue = true
-		pterm.Info.Println("GCE instance provisioning model: Spot VM")
+		e.info("GCE instance provisioning model: Spot VM")
 	}
 	return outputValue
 }
 
-func returnComputeInstanceTerminated(inputValue bool) bool {
+func (e *Estimate) returnComputeInstanceTerminated(inputValue bool) bool {
 	var outputValue bool
 	if inputValue {
 		outputValue = true
-		pterm.Info.Println("GCE instance state: Terminated")
+		e.info("GCE instance state: Terminated")
 	}
 	return outputValue
 }
 
-func returnComputeInstanceCommitment(inputValue int) int {
+// HoursMonth is the number of hours per month (same as Google Cloud Pricing Calculator)
+const HoursMonth float32 = 730
+
+// returnComputeInstanceHours returns the runtime in hours per month.
+// Returns 0 for a full month.
+func (e *Estimate) returnComputeInstanceHours(inputValue float32) float32 {
+	var outputValue float32
+	if inputValue >= HoursMonth {
+		e.info("GCE instance runtime: full month")
+	} else if inputValue > 0 {
+		outputValue = inputValue
+		e.info("GCE instance runtime: %.2f hours per month", outputValue)
+	}
+	return outputValue
+}
+
+func (e *Estimate) returnComputeInstanceCommitment(inputValue int) int {
 	var outputValue int
 	switch inputValue {
 	case 0:
 		outputValue = 0
 	case 1:
 		outputValue = inputValue
-		pterm.Info.Println("GCE instance commitment: 1 year")
+		e.info("GCE instance commitment: 1 year")
 	case 3:
 		outputValue = inputValue
-		pterm.Info.Println("GCE instance commitment: 3 years")
+		e.info("GCE instance commitment: 3 years")
 	default:
 		outputValue = 0
-		pterm.Warning.Printf("Invalid GCE instance commitment: '%v'\n", inputValue)
-		pterm.Info.Println("GCE instance commitment: no")
+		e.warning("Invalid GCE instance commitment: '%v'", inputValue)
+		e.info("GCE instance commitment: no")
 	}
 	return outputValue
 }
 
-func returnComputeInstanceName(defaultName string, inputName string) string {
+func (e *Estimate) returnComputeInstanceName(defaultName string, inputName string) string {
 	var name string
 	if len(defaultName) > 0 {
 		name = defaultName
@@ -94,78 +115,144 @@ func returnComputeInstanceName(defaultName string, inputName string) string {
 	if len(inputName) > 0 {
 		name = inputName
 	}
-	pterm.Info.Printf("GCE instance name: '%s'\n", name)
+	e.info("GCE instance name: '%s'", name)
 	return name
 }
 
-func CalcComputeInstance(pricingYml StructPricing, inputName string, inputMachineType string, inputRegion string, inputDiscount float32, inputCommitment int, inputSpot bool, inputTerminated bool) float32 {
-	name := returnComputeInstanceName("", inputName)
-	commitment := returnComputeInstanceCommitment(inputCommitment)
-	spot := returnComputeInstanceSpot(inputSpot)
-	terminated := returnComputeInstanceTerminated(inputTerminated)
-	cost := CostComputeInstance(pricingYml, inputMachineType, inputRegion)
+// CalcComputeInstance calculates the costs of the VM.
+// With hours (runtime per month, 0 for a full month) the hourly price is used.
+// Commitments are always billed for the full month.
+func (e *Estimate) CalcComputeInstance(inputName string, inputMachineType string, inputRegion string, inputDiscount float32, inputCommitment int, inputSpot bool, inputTerminated bool, inputHours float32) (float32, error) {
+	name := e.returnComputeInstanceName("", inputName)
+	commitment := e.returnComputeInstanceCommitment(inputCommitment)
+	spot := e.returnComputeInstanceSpot(inputSpot)
+	terminated := e.returnComputeInstanceTerminated(inputTerminated)
+	hours := e.returnComputeInstanceHours(inputHours)
+	cost, err := CostComputeInstance(e.Pricing, inputMachineType, inputRegion)
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE machine type '%s' in region '%s' found.", inputMachineType, inputRegion)
 	discount, discountText := returnDiscount(inputDiscount)
 
 	var price float32
+	var sud float32 // Sustained use discount (0 = not eligible)
+	var ok bool
+	if commitment > 0 && hours > 0 {
+		e.warning("Commitment of '%s' is billed for the full month and not only for %.2f hours.", name, hours)
+		hours = 0
+	}
 	if commitment == 1 {
-		price = Month1Y(cost) * discount
-		pterm.Info.Printf("1Y CUD price '%s' VM per month: $%.2f %s\n", name, price, discountText)
+		price, err = e.month1Y(cost)
+		price = price * discount
+		e.info("1Y CUD price '%s' VM per month: $%.2f %s", name, price, discountText)
 	} else if commitment == 3 {
-		price = Month3Y(cost) * discount
-		pterm.Info.Printf("3Y CUD price '%s' VM per month: $%.2f %s\n", name, price, discountText)
+		price, err = e.month3Y(cost)
+		price = price * discount
+		e.info("3Y CUD price '%s' VM per month: $%.2f %s", name, price, discountText)
 	} else if terminated {
 		price = 0
-		pterm.Info.Printf("Price '%s' VM per month: $%.2f (terminated instance)\n", name, price)
+		e.info("Price '%s' VM per month: $%.2f (terminated instance)", name, price)
+	} else if spot && hours > 0 {
+		price, err = e.hourSpot(cost)
+		price = price * hours * discount
+		e.info("Spot price '%s' VM %.2f hours per month: $%.2f %s", name, hours, price, discountText)
 	} else if spot {
-		price = MonthSpot(cost) * discount
-		pterm.Info.Printf("Spot price '%s' VM per month: $%.2f %s\n", name, price, discountText)
+		price, err = e.monthSpot(cost)
+		price = price * discount
+		e.info("Spot price '%s' VM per month: $%.2f %s", name, price, discountText)
+	} else if hours > 0 {
+		// Sustained use discount for the incremental usage brackets
+		sud, ok = SustainedUseDiscount(inputMachineType, hours)
+		price, err = Hour(cost)
+		price = price * hours * sud * discount
+		e.info("Price '%s' VM %.2f hours per month: $%.2f %s", name, hours, price, discountText)
+	} else {
+		// Monthly price includes the sustained use discount
+		sud, ok = SustainedUseDiscount(inputMachineType, HoursMonth)
+		price, err = Month(cost)
+		price = price * discount
+		e.info("Price '%s' VM per month: $%.2f %s", name, price, discountText)
+	}
+	if ok {
+		e.info("Sustained use discount '%s': %.2f", name, sud)
 	} else {
-		price = Month(cost) * discount
-		pterm.Info.Printf("Price '%s' VM per month: $%.2f %s\n", name, price, discountText)
+		sud = 0
 	}
+	if err != nil {
+		return 0, err
+	}
+	flexible := commitment == 0 && !spot && FlexibleCommitmentEligible(inputMachineType)
 	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:       File,
-			Project:    Project,
+		e.LineItems = append(e.LineItems, LineItem{
+			File:       e.File,
+			Project:    e.Project,
 			Name:       name,
 			Type:       inputMachineType,
+			Data:       hours,
 			Region:     inputRegion,
 			Resource:   "vm",
 			Commitment: commitment,
 			Discount:   discount,
+			Sud:        sud,
 			Cost:       price,
+
+			billingAccount: e.BillingAccount,
+			flexible:       flexible,
+			onDemand:       returnOnDemand(cost, hours, sud),
 		})
 	}
-	return price
+	return price, nil
 }
 
 // Google Compute Engine storage disk
 
-func CheckComputeDisk(pricingYml StructPricing, inputDiskType string) Storage {
+func CheckComputeDisk(pricingYml StructPricing, inputDiskType string) (Storage, error) {
 	disks := pricingYml.Compute.Storage
 	disk, ok := disks[inputDiskType]
-	if ok {
-		pterm.Success.Printf("Google Compute Engine storage disk type '%s' found.\n", inputDiskType)
-	} else {
-		pterm.Error.Printf("Google Compute Engine storage disk type '%s' not found!\n", inputDiskType)
-		os.Exit(1)
+	if !ok {
+		return Storage{}, &ResourceError{Resource: "Google Compute Engine storage disk type", Name: inputDiskType}
 	}
-	return disk
+	return disk, nil
 }
 
-func CostComputeDisk(pricingYml StructPricing, inputDiskType string, inputRegion string) Cost {
-	disk := CheckComputeDisk(pricingYml, inputDiskType)
+func CostComputeDisk(pricingYml StructPricing, inputDiskType string, inputRegion string) (Cost, error) {
+	disk, err := CheckComputeDisk(pricingYml, inputDiskType)
+	if err != nil {
+		return Cost{}, err
+	}
 	cost, ok := disk.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCE storage disk type '%s' in region '%s' found.\n", inputDiskType, inputRegion)
-	} else {
-		pterm.Error.Printf("GCE storage disk type '%s' in region '%s' not found!\n", inputDiskType, inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE storage disk type", Name: inputDiskType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostComputeDiskIops(pricingYml StructPricing, inputDiskType string, inputRegion string) (Cost, error) {
+	disk, err := CheckComputeDisk(pricingYml, inputDiskType)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := disk.Iops.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE storage disk type with provisioned IOPS", Name: inputDiskType, Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func returnComputeDiskName(defaultName string, inputName string) string {
+func CostComputeDiskThroughput(pricingYml StructPricing, inputDiskType string, inputRegion string) (Cost, error) {
+	disk, err := CheckComputeDisk(pricingYml, inputDiskType)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := disk.Throughput.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCE storage disk type with provisioned throughput", Name: inputDiskType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func (e *Estimate) returnComputeDiskName(defaultName string, inputName string) string {
 	var name string
 	if len(defaultName) > 0 {
 		name = defaultName
@@ -175,19 +262,28 @@ func returnComputeDiskName(defaultName string, inputName string) string {
 	if len(inputName) > 0 {
 		name = inputName
 	}
-	pterm.Info.Printf("GCE storage disk name: '%s'\n", name)
+	e.info("GCE storage disk name: '%s'", name)
 	return name
 }
 
-func CalcComputeDisk(pricingYml StructPricing, inputName string, inputStorageType string, inputStorageData float32, inputRegion string, inputDiscount float32) float32 {
-	name := returnComputeDiskName("", inputName)
+func (e *Estimate) CalcComputeDisk(inputName string, inputStorageType string, inputStorageData float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeDiskName("", inputName)
 	discount, discountText := returnDiscount(inputDiscount)
-	price := (Month(CostComputeDisk(pricingYml, inputStorageType, inputRegion)) * inputStorageData) * discount
-	pterm.Info.Printf("Price '%s' '%.2f' GiB per month: $%.2f %s\n", name, inputStorageData, price, discountText)
+	cost, err := CostComputeDisk(e.Pricing, inputStorageType, inputRegion)
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCE storage disk type '%s' in region '%s' found.", inputStorageType, inputRegion)
+	month, err := Month(cost)
+	if err != nil {
+		return 0, err
+	}
+	price := (month * inputStorageData) * discount
+	e.info("Price '%s' '%.2f' GiB per month: $%.2f %s", name, inputStorageData, price, discountText)
 	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:     File,
-			Project:  Project,
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
 			Name:     name,
 			Type:     inputStorageType,
 			Data:     inputStorageData,
@@ -197,68 +293,157 @@ func CalcComputeDisk(pricingYml StructPricing, inputName string, inputStorageTyp
 			Cost:     price,
 		})
 	}
-	return price
+	return price, nil
+}
+
+// CalcComputeDiskPartitions calculates Local SSDs, which are sold in partitions of a fixed size
+func (e *Estimate) CalcComputeDiskPartitions(inputName string, inputStorageType string, inputPartitions int, inputRegion string, inputDiscount float32) (float32, error) {
+	disk, err := CheckComputeDisk(e.Pricing, inputStorageType)
+	if err != nil {
+		return 0, err
+	}
+	if !(disk.Partition > 0) {
+		return 0, &ResourceError{Resource: "GCE storage disk type with partitions", Name: inputStorageType}
+	}
+	data := float32(inputPartitions) * disk.Partition
+	e.info("Partitions '%d' of '%.0f' GiB: %.0f GiB", inputPartitions, disk.Partition, data)
+	return e.CalcComputeDisk(inputName, inputStorageType, data, inputRegion, inputDiscount)
+}
+
+// CalcComputeDiskPerformance adds the provisioned IOPS and throughput above the included performance of the disk type
+func (e *Estimate) CalcComputeDiskPerformance(inputName string, inputStorageType string, inputIops float32, inputThroughput float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnComputeDiskName("", inputName)
+	discount, discountText := returnDiscount(inputDiscount)
+	disk, err := CheckComputeDisk(e.Pricing, inputStorageType)
+	if err != nil {
+		return 0, err
+	}
+	var price float32
+	if iops := inputIops - disk.Iops.Included; iops > 0 {
+		month, err := costMonth(CostComputeDiskIops(e.Pricing, inputStorageType, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("GCE storage disk type with provisioned IOPS '%s' in region '%s' found.", inputStorageType, inputRegion)
+		iopsPrice := (month * iops) * discount
+		e.info("Price '%s' '%.0f' provisioned IOPS ('%.0f' included) per month: $%.2f %s", name, inputIops, disk.Iops.Included, iopsPrice, discountText)
+		if iopsPrice > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
+				Name:     name,
+				Type:     inputStorageType,
+				Data:     iops,
+				Region:   inputRegion,
+				Resource: "disk-iops",
+				Discount: discount,
+				Cost:     iopsPrice,
+			})
+		}
+		price += iopsPrice
+	}
+	if throughput := inputThroughput - disk.Throughput.Included; throughput > 0 {
+		month, err := costMonth(CostComputeDiskThroughput(e.Pricing, inputStorageType, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("GCE storage disk type with provisioned throughput '%s' in region '%s' found.", inputStorageType, inputRegion)
+		throughputPrice := (month * throughput) * discount
+		e.info("Price '%s' '%.0f' MiB/s provisioned throughput ('%.0f' included) per month: $%.2f %s", name, inputThroughput, disk.Throughput.Included, throughputPrice, discountText)
+		if throughputPrice > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
+				Name:     name,
+				Type:     inputStorageType,
+				Data:     throughput,
+				Region:   inputRegion,
+				Resource: "disk-throughput",
+				Discount: discount,
+				Cost:     throughputPrice,
+			})
+		}
+		price += throughputPrice
+	}
+	return price, nil
 }
 
 // Google Compute Engine license
 
-func CheckComputeLicense(pricingYml StructPricing, inputMachineType string) License {
+func CheckComputeLicense(pricingYml StructPricing, inputMachineType string) (License, error) {
 	licenses := pricingYml.Compute.License
 	license, ok := licenses[inputMachineType]
-	if ok {
-		//pterm.Success.Printf("Google Compute Engine machine type '%s' found.\n", inputMachineType)
-	} else {
-		pterm.Error.Printf("License for Google Compute Engine machine type '%s' not found!\n", inputMachineType)
-		os.Exit(1)
+	if !ok {
+		return License{}, &ResourceError{Resource: "License for Google Compute Engine machine type", Name: inputMachineType}
 	}
-	return license
+	return license, nil
 }
 
-func CostComputeLicense(pricingYml StructPricing, inputMachineType string, inputOperatingSystem string) Cost {
-	license := CheckComputeLicense(pricingYml, inputMachineType)
+func CostComputeLicense(pricingYml StructPricing, inputMachineType string, inputOperatingSystem string) (Cost, error) {
+	license, err := CheckComputeLicense(pricingYml, inputMachineType)
+	if err != nil {
+		return Cost{}, err
+	}
 	cost, ok := license.Cost[inputOperatingSystem]
-	if ok {
-		pterm.Success.Printf("License '%s' for GCE machine type '%s' found.\n", inputOperatingSystem, inputMachineType)
-	} else {
-		pterm.Error.Printf("License '%s' for GCE machine type '%s' not found!\n", inputOperatingSystem, inputMachineType)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: fmt.Sprintf("License '%s' for GCE machine type", inputOperatingSystem), Name: inputMachineType}
 	}
-	return cost
+	return cost, nil
 }
 
-func CalcComputeLicense(pricingYml StructPricing, inputName string, inputMachineType string, inputOperatingSystem string, inputDiscount float32, inputCommitment int, inputTerminated bool) float32 {
-	name := returnComputeInstanceName("", inputName)
-	commitment := returnComputeInstanceCommitment(inputCommitment)
-	terminated := returnComputeInstanceTerminated(inputTerminated)
+// CalcComputeLicense calculates the costs of the license of the VM with the same runtime as the VM
+func (e *Estimate) CalcComputeLicense(inputName string, inputMachineType string, inputOperatingSystem string, inputDiscount float32, inputCommitment int, inputTerminated bool, inputHours float32) (float32, error) {
+	name := e.returnComputeInstanceName("", inputName)
+	commitment := e.returnComputeInstanceCommitment(inputCommitment)
+	terminated := e.returnComputeInstanceTerminated(inputTerminated)
+	hours := e.returnComputeInstanceHours(inputHours)
+	if commitment > 0 {
+		hours = 0
+	}
 	discount, discountText := returnDiscount(inputDiscount)
 	var price float32
 	if len(inputOperatingSystem) > 0 {
-		cost := CostComputeLicense(pricingYml, inputMachineType, inputOperatingSystem)
+		cost, err := CostComputeLicense(e.Pricing, inputMachineType, inputOperatingSystem)
+		if err != nil {
+			return 0, err
+		}
+		e.success("License '%s' for GCE machine type '%s' found.", inputOperatingSystem, inputMachineType)
 		if commitment == 1 {
-			price = Month1Y(cost) * discount
-			pterm.Info.Printf("1Y CUD price '%s' license per month: $%.2f %s\n", name, price, discountText)
+			price, err = e.month1Y(cost)
+			price = price * discount
+			e.info("1Y CUD price '%s' license per month: $%.2f %s", name, price, discountText)
 		} else if commitment == 3 {
-			price = Month3Y(cost) * discount
-			pterm.Info.Printf("3Y CUD price '%s' license per month: $%.2f %s\n", name, price, discountText)
+			price, err = e.month3Y(cost)
+			price = price * discount
+			e.info("3Y CUD price '%s' license per month: $%.2f %s", name, price, discountText)
 		} else if terminated {
 			price = 0
-			pterm.Info.Printf("Price '%s' license per month: $%.2f (terminated instance)\n", name, price)
+			e.info("Price '%s' license per month: $%.2f (terminated instance)", name, price)
+		} else if hours > 0 {
+			price, err = Hour(cost)
+			price = price * hours * discount
+			e.info("Price '%s' license %.2f hours per month: $%.2f %s", name, hours, price, discountText)
 		} else {
-			price = Month(cost) * discount
-			pterm.Info.Printf("Price '%s' license per month: $%.2f %s\n", name, price, discountText)
+			price, err = Month(cost)
+			price = price * discount
+			e.info("Price '%s' license per month: $%.2f %s", name, price, discountText)
+		}
+		if err != nil {
+			return 0, err
 		}
 	}
 	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:       File,
-			Project:    Project,
+		e.LineItems = append(e.LineItems, LineItem{
+			File:       e.File,
+			Project:    e.Project,
 			Name:       name,
 			Type:       inputMachineType,
+			Data:       hours,
 			Resource:   inputOperatingSystem,
 			Commitment: commitment,
 			Discount:   discount,
 			Cost:       price,
 		})
 	}
-	return price
+	return price, nil
 }
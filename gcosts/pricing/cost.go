@@ -18,81 +18,100 @@ package pricing
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
-
-	"github.com/pterm/pterm"
+	"strconv"
 )
 
-var File string
-var Project string
-
 type LineItem struct {
-	Project    string
-	Region     string
-	Resource   string
-	Name       string
-	Cost       float32
-	Type       string // Type or Class
-	Data       float32
-	Commitment int
-	Discount   float32
-	File       string
-}
+	Project    string  `json:"project" yaml:"project"`
+	Region     string  `json:"region" yaml:"region"`
+	Resource   string  `json:"resource" yaml:"resource"`
+	Name       string  `json:"name" yaml:"name"`
+	Cost       float32 `json:"cost" yaml:"cost"`
+	Type       string  `json:"type" yaml:"type"` // Type or Class
+	Data       float32 `json:"data" yaml:"data"`
+	Commitment int     `json:"commitment" yaml:"commitment"`
+	Discount   float32 `json:"discount" yaml:"discount"`
+	File       string  `json:"file" yaml:"file"`
+	Sud        float32 `json:"sud,omitempty" yaml:"sud,omitempty"` // Sustained use discount (0 = not eligible)
 
-var LineItems []LineItem
+	// Tiered usage for the aggregation with AggregateTiers
+	tiers          []Tier
+	billingAccount string
+	// Free usage is granted once per scope and not per region
+	free bool
+	// Eligible for flexible commitments (on-demand VM) with the on-demand spend without SUD and discount
+	flexible bool
+	onDemand float32
+}
 
-func Hour(cost Cost) float32 {
+func Hour(cost Cost) (float32, error) {
 	hour := cost.Hour
 	if !(hour > 0) {
-		pterm.Error.Println("Price per hour not found!")
-		os.Exit(1)
+		return 0, &PriceError{Price: "per hour"}
 	}
-	return hour
+	return hour, nil
 }
 
-func HourSpot(cost Cost) float32 {
+// HourSpot falls back to the normal hour price if the spot price is not found
+func HourSpot(cost Cost) (float32, error) {
 	hour := cost.HourSpot
 	if !(hour > 0) {
-		pterm.Warning.Println("Spot price per hour not found! Apply normal hour price.")
-		hour = Hour(cost)
+		return Hour(cost)
 	}
-	return hour
+	return hour, nil
 }
 
-func Month(cost Cost) float32 {
+func Month(cost Cost) (float32, error) {
 	month := cost.Month
 	if !(month > 0) {
-		pterm.Error.Println("Price per month not found!")
-		os.Exit(1)
+		return 0, &PriceError{Price: "per month"}
 	}
-	return month
+	return month, nil
 }
 
-func Month1Y(cost Cost) float32 {
+// costHour returns the price per hour of the cost returned by one of the Cost* functions
+func costHour(cost Cost, err error) (float32, error) {
+	if err != nil {
+		return 0, err
+	}
+	return Hour(cost)
+}
+
+// costMonth returns the price per month of the cost returned by one of the Cost* functions
+func costMonth(cost Cost, err error) (float32, error) {
+	if err != nil {
+		return 0, err
+	}
+	return Month(cost)
+}
+
+// Month1Y falls back to the normal monthly price if the 1Y CUD price is not found
+func Month1Y(cost Cost) (float32, error) {
 	month := cost.Month1Y
 	if !(month > 0) {
-		pterm.Warning.Println("1Y CUD price per month not found! Apply normal monthly price.")
-		month = Month(cost)
+		return Month(cost)
 	}
-	return month
+	return month, nil
 }
 
-func Month3Y(cost Cost) float32 {
+// Month3Y falls back to the normal monthly price if the 3Y CUD price is not found
+func Month3Y(cost Cost) (float32, error) {
 	month := cost.Month3Y
 	if !(month > 0) {
-		pterm.Warning.Println("3Y CUD price per month not found! Apply normal monthly price.")
-		month = Month(cost)
+		return Month(cost)
 	}
-	return month
+	return month, nil
 }
 
-func MonthSpot(cost Cost) float32 {
+// MonthSpot falls back to the normal monthly price if the spot price is not found
+func MonthSpot(cost Cost) (float32, error) {
 	month := cost.MonthSpot
 	if !(month > 0) {
-		pterm.Warning.Println("Spot price per month not found! Apply normal monthly price.")
-		month = Month(cost)
+		return Month(cost)
 	}
-	return month
+	return month, nil
 }
 
 func returnDiscount(inputDiscount float32) (float32, string) {
@@ -117,7 +136,6 @@ func ReturnProject(defaultProject string, inputProject string) string {
 	if len(inputProject) > 0 {
 		project = inputProject
 	}
-	pterm.Info.Printf("Project: '%s'\n", project)
 	return project
 }
 
@@ -125,38 +143,49 @@ func ReturnDiscount(defaultDiscount float32, inputDiscount float32) float32 {
 	var discount float32
 	if defaultDiscount > 0 {
 		discount = defaultDiscount
-		pterm.Info.Printf("Default discount: '%.2f'\n", discount)
 	} else {
 		discount = 0.000000
 	}
 	if inputDiscount > 0 {
 		discount = inputDiscount
-		pterm.Info.Printf("Discount: '%.2f'\n", discount)
 	}
 	return discount
 }
 
-func OverwirteDefault(pricingYml StructPricing, defaultRegion string, inputRegion string, defaultDiscount float32, inputDiscount float32) (string, float32) {
-	region := ReturnRegion(pricingYml, defaultRegion, inputRegion)
+func OverwirteDefault(pricingYml StructPricing, defaultRegion string, inputRegion string, defaultDiscount float32, inputDiscount float32) (string, float32, error) {
+	region, err := ReturnRegion(pricingYml, defaultRegion, inputRegion)
+	if err != nil {
+		return region, 0, err
+	}
 	discount := ReturnDiscount(defaultDiscount, inputDiscount)
-	return region, discount
+	return region, discount, nil
 }
 
-func ExportCsv(lineItems []LineItem, inputExportCsv string) {
+func ExportCsv(lineItems []LineItem, inputExportCsv string) error {
 	file, err := os.Create(inputExportCsv)
 	if err != nil {
-		pterm.Error.Println(err)
-		os.Exit(9)
+		return err
 	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return WriteCsv(file, lineItems)
+}
 
-	w := csv.NewWriter(file)
+// WriteCsv writes the line items as CSV to the writer
+func WriteCsv(writer io.Writer, lineItems []LineItem) error {
+	w := csv.NewWriter(writer)
 
 	// Old (<3.0.0) header
 	// PROJECT;REGION;RESOURCE;NAME;COST;TYPE;DATA;CLASS;COMMITMENT;DISCOUNT;FILE
 	data := [][]string{
-		{"Project", "Region", "Resource", "Type/Class", "Name", "Cost", "Data", "CUD", "Discount", "File"},
+		{"Project", "Region", "Resource", "Type/Class", "Name", "Cost", "Data", "CUD", "Discount", "File", "SUD"},
 	}
 	for _, lineItem := range lineItems {
+		var sud string
+		if lineItem.Sud > 0 {
+			sud = fmt.Sprintf("%f", lineItem.Sud)
+		}
 		data = append(data, []string{
 			lineItem.Project,
 			lineItem.Region,
@@ -168,10 +197,85 @@ func ExportCsv(lineItems []LineItem, inputExportCsv string) {
 			fmt.Sprintf("%v", lineItem.Commitment),
 			fmt.Sprintf("%f", lineItem.Discount),
 			lineItem.File,
+			sud,
 		})
 	}
-	if err := w.WriteAll(data); err != nil {
-		pterm.Error.Println(err)
-		os.Exit(8)
+	return w.WriteAll(data)
+}
+
+// ReadCsv reads the line items of a CSV file exported with ExportCsv
+func ReadCsv(inputCsv string) ([]LineItem, error) {
+	file, err := os.Open(inputCsv)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("CSV file '%s' is empty", inputCsv)
+	}
+	// Columns by header name
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"Project", "Region", "Resource", "Type/Class", "Name", "Cost", "Data", "CUD", "Discount", "File"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column '%s' not found in CSV file '%s'", name, inputCsv)
+		}
+	}
+	var lineItems []LineItem
+	for line, record := range records[1:] {
+		float := func(name string) (float32, error) {
+			value, err := strconv.ParseFloat(record[columns[name]], 32)
+			if err != nil {
+				return 0, fmt.Errorf("column '%s' in line %d of CSV file '%s': %w", name, line+2, inputCsv, err)
+			}
+			return float32(value), nil
+		}
+		cost, err := float("Cost")
+		if err != nil {
+			return nil, err
+		}
+		data, err := float("Data")
+		if err != nil {
+			return nil, err
+		}
+		discount, err := float("Discount")
+		if err != nil {
+			return nil, err
+		}
+		commitment, err := strconv.Atoi(record[columns["CUD"]])
+		if err != nil {
+			return nil, fmt.Errorf("column 'CUD' in line %d of CSV file '%s': %w", line+2, inputCsv, err)
+		}
+		// Optional (CSV files of older versions)
+		var sud float32
+		if _, ok := columns["SUD"]; ok && len(record[columns["SUD"]]) > 0 {
+			sud, err = float("SUD")
+			if err != nil {
+				return nil, err
+			}
+		}
+		lineItems = append(lineItems, LineItem{
+			Project:    record[columns["Project"]],
+			Region:     record[columns["Region"]],
+			Resource:   record[columns["Resource"]],
+			Name:       record[columns["Name"]],
+			Cost:       cost,
+			Type:       record[columns["Type/Class"]],
+			Data:       data,
+			Commitment: commitment,
+			Discount:   discount,
+			File:       record[columns["File"]],
+			Sud:        sud,
+		})
 	}
+	return lineItems, nil
 }
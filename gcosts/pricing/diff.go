@@ -0,0 +1,171 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Status of a line item in a diff
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// DiffItem is a line item that was added, removed or whose costs changed.
+// Line items are matched by project, file, resource and name.
+type DiffItem struct {
+	Status   string  `json:"status" yaml:"status"`
+	Project  string  `json:"project" yaml:"project"`
+	File     string  `json:"file" yaml:"file"`
+	Resource string  `json:"resource" yaml:"resource"`
+	Name     string  `json:"name" yaml:"name"`
+	OldType  string  `json:"old-type" yaml:"old-type"`
+	NewType  string  `json:"new-type" yaml:"new-type"`
+	OldCost  float32 `json:"old-cost" yaml:"old-cost"`
+	NewCost  float32 `json:"new-cost" yaml:"new-cost"`
+	Delta    float32 `json:"delta" yaml:"delta"`
+}
+
+// ResourceDelta is the cost delta of all line items of one resource
+type ResourceDelta struct {
+	Resource string  `json:"resource" yaml:"resource"`
+	OldCost  float32 `json:"old-cost" yaml:"old-cost"`
+	NewCost  float32 `json:"new-cost" yaml:"new-cost"`
+	Delta    float32 `json:"delta" yaml:"delta"`
+}
+
+// Diff is the cost difference between two calculations
+type Diff struct {
+	OldTotal  float32         `json:"old-total" yaml:"old-total"`
+	NewTotal  float32         `json:"new-total" yaml:"new-total"`
+	Delta     float32         `json:"delta" yaml:"delta"`
+	Resources []ResourceDelta `json:"resources" yaml:"resources"`
+	Items     []DiffItem      `json:"items" yaml:"items"`
+}
+
+type diffKey struct {
+	Project  string
+	File     string
+	Resource string
+	Name     string
+}
+
+// DiffLineItems compares the old and new line items.
+// Line items with the same project, file, resource and name are summed up before they are compared.
+// Unchanged line items are not part of the diff.
+func DiffLineItems(oldLineItems []LineItem, newLineItems []LineItem) Diff {
+	items := map[diffKey]*DiffItem{}
+	var keys []diffKey
+	item := func(lineItem LineItem) *DiffItem {
+		key := diffKey{lineItem.Project, lineItem.File, lineItem.Resource, lineItem.Name}
+		if _, ok := items[key]; !ok {
+			keys = append(keys, key)
+			items[key] = &DiffItem{
+				Project:  key.Project,
+				File:     key.File,
+				Resource: key.Resource,
+				Name:     key.Name,
+			}
+		}
+		return items[key]
+	}
+	oldFound := map[diffKey]bool{}
+	for _, lineItem := range oldLineItems {
+		i := item(lineItem)
+		i.OldCost += lineItem.Cost
+		i.OldType = joinType(i.OldType, lineItem.Type)
+		oldFound[diffKey{lineItem.Project, lineItem.File, lineItem.Resource, lineItem.Name}] = true
+	}
+	newFound := map[diffKey]bool{}
+	for _, lineItem := range newLineItems {
+		i := item(lineItem)
+		i.NewCost += lineItem.Cost
+		i.NewType = joinType(i.NewType, lineItem.Type)
+		newFound[diffKey{lineItem.Project, lineItem.File, lineItem.Resource, lineItem.Name}] = true
+	}
+
+	diff := Diff{
+		Resources: []ResourceDelta{},
+		Items:     []DiffItem{},
+	}
+	resources := map[string]*ResourceDelta{}
+	var resourceNames []string
+	for _, key := range keys {
+		i := items[key]
+		i.Delta = i.NewCost - i.OldCost
+		diff.OldTotal += i.OldCost
+		diff.NewTotal += i.NewCost
+
+		resource, ok := resources[i.Resource]
+		if !ok {
+			resource = &ResourceDelta{Resource: i.Resource}
+			resources[i.Resource] = resource
+			resourceNames = append(resourceNames, i.Resource)
+		}
+		resource.OldCost += i.OldCost
+		resource.NewCost += i.NewCost
+
+		switch {
+		case !oldFound[key]:
+			i.Status = DiffAdded
+		case !newFound[key]:
+			i.Status = DiffRemoved
+		case changed(i.Delta) || i.OldType != i.NewType:
+			i.Status = DiffChanged
+		default:
+			continue
+		}
+		diff.Items = append(diff.Items, *i)
+	}
+	diff.Delta = diff.NewTotal - diff.OldTotal
+
+	for _, name := range resourceNames {
+		resource := resources[name]
+		resource.Delta = resource.NewCost - resource.OldCost
+		if changed(resource.Delta) {
+			diff.Resources = append(diff.Resources, *resource)
+		}
+	}
+	sort.SliceStable(diff.Resources, func(a, b int) bool {
+		return diff.Resources[a].Resource < diff.Resources[b].Resource
+	})
+	return diff
+}
+
+// changed returns true if the delta is at least one cent
+func changed(delta float32) bool {
+	return math.Abs(float64(delta)) >= 0.005
+}
+
+// joinType joins the different types of line items with the same key sorted, e.g. "traffic, traffic-cn".
+// Sorted types do not change if only the order of the line items changes.
+func joinType(types string, lineItemType string) string {
+	if len(types) == 0 {
+		return lineItemType
+	}
+	split := strings.Split(types, ", ")
+	if slices.Contains(split, lineItemType) {
+		return types
+	}
+	split = append(split, lineItemType)
+	slices.Sort(split)
+	return strings.Join(split, ", ")
+}
@@ -0,0 +1,273 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiffLineItems(t *testing.T) {
+	item := func(project, file, resource, name, lineItemType string, cost float32) LineItem {
+		return LineItem{Project: project, File: file, Resource: resource, Name: name, Type: lineItemType, Cost: cost}
+	}
+	tests := []struct {
+		name          string
+		old           []LineItem
+		new           []LineItem
+		wantItems     []DiffItem
+		wantResources []ResourceDelta
+		wantDelta     float32
+	}{
+		{
+			name:          "unchanged",
+			old:           []LineItem{item("p", "a.yml", "vm", "web", "e2-small", 10)},
+			new:           []LineItem{item("p", "a.yml", "vm", "web", "e2-small", 10.001)},
+			wantItems:     []DiffItem{},
+			wantResources: []ResourceDelta{},
+			wantDelta:     0.001,
+		},
+		{
+			name: "changed cost",
+			old:  []LineItem{item("p", "a.yml", "vm", "web", "e2-small", 10)},
+			new:  []LineItem{item("p", "a.yml", "vm", "web", "e2-small", 15)},
+			wantItems: []DiffItem{
+				{Status: DiffChanged, Project: "p", File: "a.yml", Resource: "vm", Name: "web", OldType: "e2-small", NewType: "e2-small", OldCost: 10, NewCost: 15, Delta: 5},
+			},
+			wantResources: []ResourceDelta{{Resource: "vm", OldCost: 10, NewCost: 15, Delta: 5}},
+			wantDelta:     5,
+		},
+		{
+			name: "changed type with same cost",
+			old:  []LineItem{item("p", "a.yml", "vm", "web", "e2-small", 10)},
+			new:  []LineItem{item("p", "a.yml", "vm", "web", "t2d-small", 10)},
+			wantItems: []DiffItem{
+				{Status: DiffChanged, Project: "p", File: "a.yml", Resource: "vm", Name: "web", OldType: "e2-small", NewType: "t2d-small", OldCost: 10, NewCost: 10},
+			},
+			wantResources: []ResourceDelta{},
+		},
+		{
+			name: "added and removed",
+			old:  []LineItem{item("p", "a.yml", "vm", "old", "e2-small", 10)},
+			new:  []LineItem{item("p", "a.yml", "bucket", "new", "standard", 2)},
+			wantItems: []DiffItem{
+				{Status: DiffRemoved, Project: "p", File: "a.yml", Resource: "vm", Name: "old", OldType: "e2-small", OldCost: 10, Delta: -10},
+				{Status: DiffAdded, Project: "p", File: "a.yml", Resource: "bucket", Name: "new", NewType: "standard", NewCost: 2, Delta: 2},
+			},
+			wantResources: []ResourceDelta{
+				{Resource: "bucket", NewCost: 2, Delta: 2},
+				{Resource: "vm", OldCost: 10, Delta: -10},
+			},
+			wantDelta: -8,
+		},
+		{
+			name: "matched by project, file, resource and name",
+			old: []LineItem{
+				item("p1", "a.yml", "vm", "web", "e2-small", 10),
+				item("p2", "a.yml", "vm", "web", "e2-small", 20),
+				item("p1", "b.yml", "vm", "web", "e2-small", 30),
+				item("p1", "a.yml", "disk", "web", "pd-ssd", 40),
+			},
+			new: []LineItem{
+				item("p1", "a.yml", "disk", "web", "pd-ssd", 40),
+				item("p1", "b.yml", "vm", "web", "e2-small", 30),
+				item("p2", "a.yml", "vm", "web", "e2-small", 25),
+				item("p1", "a.yml", "vm", "web", "e2-small", 10),
+			},
+			wantItems: []DiffItem{
+				{Status: DiffChanged, Project: "p2", File: "a.yml", Resource: "vm", Name: "web", OldType: "e2-small", NewType: "e2-small", OldCost: 20, NewCost: 25, Delta: 5},
+			},
+			wantResources: []ResourceDelta{{Resource: "vm", OldCost: 60, NewCost: 65, Delta: 5}},
+			wantDelta:     5,
+		},
+		{
+			name: "duplicates summed up",
+			old: []LineItem{
+				item("p", "a.yml", "traffic", "egress", "traffic", 1),
+				item("p", "a.yml", "traffic", "egress", "traffic-cn", 2),
+			},
+			new: []LineItem{
+				item("p", "a.yml", "traffic", "egress", "traffic-cn", 4),
+				item("p", "a.yml", "traffic", "egress", "traffic", 1),
+			},
+			wantItems: []DiffItem{
+				{Status: DiffChanged, Project: "p", File: "a.yml", Resource: "traffic", Name: "egress", OldType: "traffic, traffic-cn", NewType: "traffic, traffic-cn", OldCost: 3, NewCost: 5, Delta: 2},
+			},
+			wantResources: []ResourceDelta{{Resource: "traffic", OldCost: 3, NewCost: 5, Delta: 2}},
+			wantDelta:     2,
+		},
+		{
+			name: "duplicates in different order",
+			old: []LineItem{
+				item("p", "a.yml", "traffic", "egress", "traffic-cn", 2),
+				item("p", "a.yml", "traffic", "egress", "traffic", 1),
+			},
+			new: []LineItem{
+				item("p", "a.yml", "traffic", "egress", "traffic", 1),
+				item("p", "a.yml", "traffic", "egress", "traffic-cn", 2),
+			},
+			wantItems:     []DiffItem{},
+			wantResources: []ResourceDelta{},
+		},
+		{
+			name: "duplicates with the same type",
+			old: []LineItem{
+				item("p", "a.yml", "vm", "web", "e2-small", 10),
+				item("p", "a.yml", "vm", "web", "e2-small", 10),
+			},
+			new:           []LineItem{item("p", "a.yml", "vm", "web", "e2-small", 20)},
+			wantItems:     []DiffItem{},
+			wantResources: []ResourceDelta{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff := DiffLineItems(test.old, test.new)
+			if len(diff.Items) != len(test.wantItems) {
+				t.Fatalf("items = %+v, want %+v", diff.Items, test.wantItems)
+			}
+			for i, want := range test.wantItems {
+				got := diff.Items[i]
+				if got.Status != want.Status || got.Project != want.Project || got.File != want.File ||
+					got.Resource != want.Resource || got.Name != want.Name ||
+					got.OldType != want.OldType || got.NewType != want.NewType ||
+					!testEqual(got.OldCost, want.OldCost) || !testEqual(got.NewCost, want.NewCost) || !testEqual(got.Delta, want.Delta) {
+					t.Errorf("item %d = %+v, want %+v", i, got, want)
+				}
+			}
+			if len(diff.Resources) != len(test.wantResources) {
+				t.Fatalf("resources = %+v, want %+v", diff.Resources, test.wantResources)
+			}
+			for i, want := range test.wantResources {
+				got := diff.Resources[i]
+				if got.Resource != want.Resource || !testEqual(got.OldCost, want.OldCost) ||
+					!testEqual(got.NewCost, want.NewCost) || !testEqual(got.Delta, want.Delta) {
+					t.Errorf("resource %d = %+v, want %+v", i, got, want)
+				}
+			}
+			if !testEqual(diff.Delta, test.wantDelta) {
+				t.Errorf("delta = %v, want %v", diff.Delta, test.wantDelta)
+			}
+		})
+	}
+}
+
+func TestReadCsv(t *testing.T) {
+	header := "Project,Region,Resource,Type/Class,Name,Cost,Data,CUD,Discount,File,SUD\n"
+	tests := []struct {
+		name    string
+		csv     string
+		want    []LineItem
+		wantErr string
+	}{
+		{
+			name: "exported line items",
+			csv:  header + "p,us-central1,vm,e2-small,web,12.500000,0.000000,1,0.900000,a.yml,0.800000\n",
+			want: []LineItem{{Project: "p", Region: "us-central1", Resource: "vm", Type: "e2-small", Name: "web", Cost: 12.5, Commitment: 1, Discount: 0.9, File: "a.yml", Sud: 0.8}},
+		},
+		{
+			name: "without sustained use discount",
+			csv:  header + "p,us-central1,bucket,standard,data,2.000000,100.000000,0,1.000000,a.yml,\n",
+			want: []LineItem{{Project: "p", Region: "us-central1", Resource: "bucket", Type: "standard", Name: "data", Cost: 2, Data: 100, Discount: 1, File: "a.yml"}},
+		},
+		{
+			name: "older version without SUD column",
+			csv: "File,Discount,CUD,Data,Cost,Name,Type/Class,Resource,Region,Project\n" +
+				"a.yml,1,0,0,3,web,e2-small,vm,us-central1,p\n",
+			want: []LineItem{{Project: "p", Region: "us-central1", Resource: "vm", Type: "e2-small", Name: "web", Cost: 3, Discount: 1, File: "a.yml"}},
+		},
+		{
+			name:    "empty",
+			csv:     "",
+			wantErr: "is empty",
+		},
+		{
+			name:    "missing column",
+			csv:     "Project,Region,Resource,Type/Class,Name,Cost,Data,CUD,Discount\n",
+			wantErr: "column 'File' not found",
+		},
+		{
+			name:    "invalid cost",
+			csv:     header + "p,us-central1,vm,e2-small,web,1.0,0,0,1,a.yml,\n" + "p,us-central1,vm,e2-small,web,free,0,0,1,a.yml,\n",
+			wantErr: "column 'Cost' in line 3",
+		},
+		{
+			name:    "invalid commitment",
+			csv:     header + "p,us-central1,vm,e2-small,web,1.0,0,1.5,1,a.yml,\n",
+			wantErr: "column 'CUD' in line 2",
+		},
+		{
+			name:    "invalid sustained use discount",
+			csv:     header + "p,us-central1,vm,e2-small,web,1.0,0,0,1,a.yml,20%\n",
+			wantErr: "column 'SUD' in line 2",
+		},
+		{
+			name:    "wrong number of fields",
+			csv:     header + "p,us-central1,vm\n",
+			wantErr: "wrong number of fields",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "costs.csv")
+			if err := os.WriteFile(path, []byte(test.csv), 0644); err != nil {
+				t.Fatal(err)
+			}
+			lineItems, err := ReadCsv(path)
+			if len(test.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lineItems) != len(test.want) {
+				t.Fatalf("line items = %+v, want %+v", lineItems, test.want)
+			}
+			for i, want := range test.want {
+				got := lineItems[i]
+				if got.Project != want.Project || got.Region != want.Region || got.Resource != want.Resource ||
+					got.Type != want.Type || got.Name != want.Name || got.Commitment != want.Commitment || got.File != want.File ||
+					!testEqual(got.Cost, want.Cost) || !testEqual(got.Data, want.Data) ||
+					!testEqual(got.Discount, want.Discount) || !testEqual(got.Sud, want.Sud) {
+					t.Errorf("line item %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadCsvWriteCsv(t *testing.T) {
+	lineItems := []LineItem{
+		{Project: "p", Region: "europe-west4", Resource: "vm", Type: "n1-standard-1", Name: "a,b", Cost: 24.5, Commitment: 3, Discount: 1, File: "my usage.yml", Sud: 0.7},
+	}
+	path := filepath.Join(t.TempDir(), "costs.csv")
+	if err := ExportCsv(lineItems, path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadCsv(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := DiffLineItems(lineItems, got)
+	if len(got) != 1 || len(diff.Items) != 0 || got[0].Sud != lineItems[0].Sud || got[0].Commitment != 3 {
+		t.Errorf("line items = %+v, want %+v", got, lineItems)
+	}
+}
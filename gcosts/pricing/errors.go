@@ -0,0 +1,89 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Use errors.Is to check which kind of information is missing in the pricing information
+var (
+	ErrRegionNotFound   = errors.New("region not found")
+	ErrResourceNotFound = errors.New("resource not found")
+	ErrPriceNotFound    = errors.New("price not found")
+)
+
+// RegionError is returned if a Google Cloud region is unknown
+type RegionError struct {
+	Region string
+}
+
+func (e *RegionError) Error() string {
+	return fmt.Sprintf("Google Cloud region '%s' not found!", e.Region)
+}
+
+func (e *RegionError) Unwrap() error {
+	return ErrRegionNotFound
+}
+
+// ResourceError is returned if a resource (machine type, disk type, storage class, ...)
+// is unknown or is not available in the region
+type ResourceError struct {
+	Resource string // Description of the resource, e.g. "GCE machine type"
+	Name     string // Name of the resource, e.g. "n1-standard-8"
+	Region   string
+}
+
+func (e *ResourceError) Error() string {
+	if len(e.Name) > 0 && len(e.Region) > 0 {
+		return fmt.Sprintf("%s '%s' in region '%s' not found!", e.Resource, e.Name, e.Region)
+	} else if len(e.Region) > 0 {
+		return fmt.Sprintf("%s in region '%s' not found!", e.Resource, e.Region)
+	}
+	return fmt.Sprintf("%s '%s' not found!", e.Resource, e.Name)
+}
+
+func (e *ResourceError) Unwrap() error {
+	return ErrResourceNotFound
+}
+
+// PriceError is returned if a resource was found but the price is missing
+type PriceError struct {
+	Price string // e.g. "per hour"
+}
+
+func (e *PriceError) Error() string {
+	return fmt.Sprintf("Price %s not found!", e.Price)
+}
+
+func (e *PriceError) Unwrap() error {
+	return ErrPriceNotFound
+}
+
+// MachineTypeError is returned if a custom machine type is not valid
+type MachineTypeError struct {
+	Name   string // Name of the custom machine type, e.g. "n2-custom-6-20480"
+	Reason string // e.g. "number of vCPUs must be a multiple of 2"
+}
+
+func (e *MachineTypeError) Error() string {
+	return fmt.Sprintf("Custom machine type '%s' invalid, %s!", e.Name, e.Reason)
+}
+
+func (e *MachineTypeError) Unwrap() error {
+	return ErrResourceNotFound
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+// Estimate holds the pricing information, the defaults and the calculated line items of one cost calculation.
+// Every Calc* method appends its line items to the estimate and emits its diagnostics to the logger.
+// Without logger the calculation is silent.
+// Line items of tiered usage (e.g. within the free usage of Cloud Run or BigQuery) can have no costs
+// until AggregateTiers prices them on the total usage and removes the line items without costs.
+// Call AggregateTiers after the last Calc* method and before the line items are used.
+// Use one estimate per calculation. An estimate must not be shared between goroutines,
+// but several estimates can share the same pricing information.
+type Estimate struct {
+	Pricing        StructPricing
+	File           string  // Usage file stored in the line items
+	Project        string  // Default project stored in the line items
+	Region         string  // Default region
+	Discount       float32 // Default discount
+	BillingAccount string  // Default billing account for the aggregation of tiered usage
+	LineItems      []LineItem
+	Budgets        []Budget
+	Commitments    []Commitment
+	Logger         Logger
+
+	gkeCredit map[string]float32 // Used GKE free tier credit per billing account
+}
+
+func NewEstimate(pricingYml StructPricing) *Estimate {
+	return &Estimate{
+		Pricing: pricingYml,
+		Project: "default-project-id",
+		Region:  "us-central1",
+	}
+}
+
+// SetDefaults overwrites the default project, region and discount of the estimate if they are set
+func (e *Estimate) SetDefaults(inputProject string, inputRegion string, inputDiscount float32) error {
+	project := ReturnProject(e.Project, inputProject)
+	e.info("Project: '%s'", project)
+	region, err := e.returnRegion(e.Region, inputRegion)
+	if err != nil {
+		return err
+	}
+	e.Project = project
+	e.Region = region
+	e.Discount = e.returnDiscount(e.Discount, inputDiscount)
+	return nil
+}
+
+// SetBillingAccount overwrites the default billing account of the estimate if it is set
+func (e *Estimate) SetBillingAccount(inputBillingAccount string) {
+	if len(inputBillingAccount) > 0 {
+		e.BillingAccount = inputBillingAccount
+	}
+	if len(e.BillingAccount) > 0 {
+		e.info("Billing account: '%s'", e.BillingAccount)
+	}
+}
+
+// OverwriteDefault returns the region and discount of a resource.
+// The defaults of the estimate are used if they are not set.
+func (e *Estimate) OverwriteDefault(inputRegion string, inputDiscount float32) (string, float32, error) {
+	region, err := e.returnRegion(e.Region, inputRegion)
+	if err != nil {
+		return region, 0, err
+	}
+	discount := e.returnDiscount(e.Discount, inputDiscount)
+	return region, discount, nil
+}
+
+func (e *Estimate) returnRegion(defaultRegion string, inputRegion string) (string, error) {
+	region, err := ReturnRegion(e.Pricing, defaultRegion, inputRegion)
+	if err != nil {
+		return region, err
+	}
+	if len(inputRegion) > 0 {
+		e.success("Google Cloud region '%s' found.", inputRegion)
+	}
+	e.info("Google Cloud region: '%s'", region)
+	return region, nil
+}
+
+func (e *Estimate) returnDiscount(defaultDiscount float32, inputDiscount float32) float32 {
+	if defaultDiscount > 0 {
+		e.info("Default discount: '%.2f'", defaultDiscount)
+	}
+	if inputDiscount > 0 {
+		e.info("Discount: '%.2f'", inputDiscount)
+	}
+	return ReturnDiscount(defaultDiscount, inputDiscount)
+}
+
+func (e *Estimate) month1Y(cost Cost) (float32, error) {
+	if !(cost.Month1Y > 0) {
+		e.warning("1Y CUD price per month not found! Apply normal monthly price.")
+	}
+	return Month1Y(cost)
+}
+
+func (e *Estimate) month3Y(cost Cost) (float32, error) {
+	if !(cost.Month3Y > 0) {
+		e.warning("3Y CUD price per month not found! Apply normal monthly price.")
+	}
+	return Month3Y(cost)
+}
+
+func (e *Estimate) monthSpot(cost Cost) (float32, error) {
+	if !(cost.MonthSpot > 0) {
+		e.warning("Spot price per month not found! Apply normal monthly price.")
+	}
+	return MonthSpot(cost)
+}
+
+func (e *Estimate) hourSpot(cost Cost) (float32, error) {
+	if !(cost.HourSpot > 0) {
+		e.warning("Spot price per hour not found! Apply normal hourly price.")
+	}
+	return HourSpot(cost)
+}
+
+// Total returns the sum of the costs of all line items
+func (e *Estimate) Total() float32 {
+	var total float32
+	for _, lineItem := range e.LineItems {
+		total = total + lineItem.Cost
+	}
+	return total
+}
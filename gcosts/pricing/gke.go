@@ -0,0 +1,194 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+// Google Kubernetes Engine (GKE)
+
+// Modes of operation of a GKE cluster
+const (
+	GkeModeStandard  = "standard"
+	GkeModeAutopilot = "autopilot"
+)
+
+// GkeFreeTierCredit is the monthly credit per billing account for the cluster management fee
+// of zonal and Autopilot clusters
+const GkeFreeTierCredit float32 = 74.40
+
+// CheckGkeMode returns an error if the mode of operation is unknown
+func CheckGkeMode(inputMode string) error {
+	switch inputMode {
+	case GkeModeStandard, GkeModeAutopilot:
+		return nil
+	}
+	return &ResourceError{Resource: "GKE mode of operation", Name: inputMode}
+}
+
+func CostGkeCluster(pricingYml StructPricing, inputRegion string) (Cost, error) {
+	cost, ok := pricingYml.Gke.Cluster.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GKE cluster management fee", Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostGkeAutopilotCpu(pricingYml StructPricing, inputRegion string) (Cost, error) {
+	cost, ok := pricingYml.Gke.Autopilot.Cpu.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GKE Autopilot vCPU", Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostGkeAutopilotRam(pricingYml StructPricing, inputRegion string) (Cost, error) {
+	cost, ok := pricingYml.Gke.Autopilot.Ram.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GKE Autopilot memory", Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostGkeAutopilotStorage(pricingYml StructPricing, inputRegion string) (Cost, error) {
+	cost, ok := pricingYml.Gke.Autopilot.Storage.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GKE Autopilot ephemeral storage", Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func (e *Estimate) returnGkeClusterName(defaultName string, inputName string) string {
+	var name string
+	if len(defaultName) > 0 {
+		name = defaultName
+	} else {
+		name = "default-cluster-name"
+	}
+	if len(inputName) > 0 {
+		name = inputName
+	}
+	e.info("GKE cluster name: '%s'", name)
+	return name
+}
+
+func (e *Estimate) returnGkeMode(inputValue string) string {
+	outputValue := GkeModeStandard
+	if len(inputValue) > 0 {
+		outputValue = inputValue
+	}
+	e.info("GKE mode of operation: '%s'", outputValue)
+	return outputValue
+}
+
+// CalcGkeCluster calculates the cluster management fee, which is the same for Standard and Autopilot clusters.
+// The free tier credit of the billing account is applied to the first zonal or Autopilot clusters.
+func (e *Estimate) CalcGkeCluster(inputName string, inputMode string, inputRegional bool, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnGkeClusterName("", inputName)
+	mode := e.returnGkeMode(inputMode)
+	if err := CheckGkeMode(mode); err != nil {
+		return 0, err
+	}
+	discount, discountText := returnDiscount(inputDiscount)
+	month, err := costMonth(CostGkeCluster(e.Pricing, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GKE cluster management fee in region '%s' found.", inputRegion)
+	price := month * discount
+	if mode == GkeModeAutopilot || !inputRegional {
+		if e.gkeCredit == nil {
+			e.gkeCredit = map[string]float32{}
+		}
+		credit := min(GkeFreeTierCredit-e.gkeCredit[e.BillingAccount], price)
+		if credit > 0 {
+			e.gkeCredit[e.BillingAccount] = e.gkeCredit[e.BillingAccount] + credit
+			price = price - credit
+			e.info("GKE free tier credit for '%s' cluster management fee: $%.2f", name, credit)
+		}
+	}
+	e.info("Price '%s' cluster management fee per month: $%.2f %s", name, price, discountText)
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Name:     name,
+			Type:     mode,
+			Region:   inputRegion,
+			Resource: "gke-cluster",
+			Discount: discount,
+			Cost:     price,
+		})
+	}
+	return price, nil
+}
+
+// CalcGkeAutopilot calculates the vCPU, memory and ephemeral storage requests of the pods of an Autopilot cluster
+func (e *Estimate) CalcGkeAutopilot(inputName string, inputCpu float32, inputRam float32, inputStorage float32, inputRegion string, inputDiscount float32, inputCommitment int, inputSpot bool) (float32, error) {
+	name := e.returnGkeClusterName("", inputName)
+	commitment := e.returnComputeInstanceCommitment(inputCommitment)
+	spot := e.returnComputeInstanceSpot(inputSpot)
+	discount, discountText := returnDiscount(inputDiscount)
+	requests := []struct {
+		resource string
+		data     float32
+		cost     func(StructPricing, string) (Cost, error)
+	}{
+		{"cpu", inputCpu, CostGkeAutopilotCpu},
+		{"ram", inputRam, CostGkeAutopilotRam},
+		{"storage", inputStorage, CostGkeAutopilotStorage},
+	}
+	var total float32
+	for _, request := range requests {
+		if !(request.data > 0) {
+			continue
+		}
+		cost, err := request.cost(e.Pricing, inputRegion)
+		if err != nil {
+			return 0, err
+		}
+		e.success("GKE Autopilot '%s' in region '%s' found.", request.resource, inputRegion)
+		var month float32
+		switch {
+		case commitment == 1:
+			month, err = e.month1Y(cost)
+		case commitment == 3:
+			month, err = e.month3Y(cost)
+		case spot:
+			month, err = e.monthSpot(cost)
+		default:
+			month, err = Month(cost)
+		}
+		if err != nil {
+			return 0, err
+		}
+		price := (month * request.data) * discount
+		e.info("Price '%s' Autopilot '%s' '%.2f' per month: $%.2f %s", name, request.resource, request.data, price, discountText)
+		if price > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:       e.File,
+				Project:    e.Project,
+				Name:       name,
+				Type:       request.resource,
+				Data:       request.data,
+				Region:     inputRegion,
+				Resource:   "gke-autopilot",
+				Commitment: commitment,
+				Discount:   discount,
+				Cost:       price,
+			})
+		}
+		total += price
+	}
+	return total, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+func TestCalcGkeClusterFreeTier(t *testing.T) {
+	pricingYml := testPricing(t, `
+gke:
+  cluster:
+    cost:
+      us-central1: {hour: 0.1, month: 73}
+`)
+	type cluster struct {
+		mode           string
+		regional       bool
+		billingAccount string
+	}
+	tests := []struct {
+		name     string
+		clusters []cluster
+		want     []float32
+	}{
+		{"zonal cluster", []cluster{{GkeModeStandard, false, ""}}, []float32{0}},
+		{"Autopilot cluster", []cluster{{GkeModeAutopilot, false, ""}}, []float32{0}},
+		{"regional cluster", []cluster{{GkeModeStandard, true, ""}}, []float32{73}},
+		{"credit used by first cluster", []cluster{{GkeModeStandard, false, ""}, {GkeModeAutopilot, false, ""}}, []float32{0, 73 - (GkeFreeTierCredit - 73)}},
+		{"credit per billing account", []cluster{{GkeModeStandard, false, "a"}, {GkeModeStandard, false, "b"}}, []float32{0, 0}},
+		{"regional cluster does not use credit", []cluster{{GkeModeStandard, true, ""}, {GkeModeStandard, false, ""}}, []float32{73, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			for i, c := range tt.clusters {
+				e.BillingAccount = c.billingAccount
+				price, err := e.CalcGkeCluster("gke", c.mode, c.regional, "us-central1", 1)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !testEqual(price, tt.want[i]) {
+					t.Errorf("price of cluster %d = %f, want %f", i, price, tt.want[i])
+				}
+			}
+		})
+	}
+}
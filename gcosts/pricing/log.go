@@ -0,0 +1,101 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+)
+
+// Level of a diagnostic
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelSuccess
+	LevelWarning
+)
+
+// MarshalText encodes the level as text in JSON and YAML
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l Level) String() string {
+	switch l {
+	case LevelSuccess:
+		return "success"
+	case LevelWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Diagnostic is a message emitted by an estimate during the calculation
+type Diagnostic struct {
+	Level   Level  `json:"level" yaml:"level"`
+	Message string `json:"message" yaml:"message"`
+	File    string `json:"file" yaml:"file"` // Usage file that was calculated
+}
+
+// Logger receives the diagnostics of an estimate
+type Logger interface {
+	Log(diagnostic Diagnostic)
+}
+
+// Collector is a logger that stores all diagnostics
+type Collector struct {
+	Diagnostics []Diagnostic
+}
+
+func (c *Collector) Log(diagnostic Diagnostic) {
+	c.Diagnostics = append(c.Diagnostics, diagnostic)
+}
+
+// Warnings returns only the collected diagnostics with level warning
+func (c *Collector) Warnings() []Diagnostic {
+	var warnings []Diagnostic
+	for _, diagnostic := range c.Diagnostics {
+		if diagnostic.Level == LevelWarning {
+			warnings = append(warnings, diagnostic)
+		}
+	}
+	return warnings
+}
+
+func (e *Estimate) log(level Level, format string, a ...any) {
+	// Silent without logger
+	if e.Logger == nil {
+		return
+	}
+	e.Logger.Log(Diagnostic{
+		Level:   level,
+		Message: fmt.Sprintf(format, a...),
+		File:    e.File,
+	})
+}
+
+func (e *Estimate) info(format string, a ...any) {
+	e.log(LevelInfo, format, a...)
+}
+
+func (e *Estimate) success(format string, a ...any) {
+	e.log(LevelSuccess, format, a...)
+}
+
+func (e *Estimate) warning(format string, a ...any) {
+	e.log(LevelWarning, format, a...)
+}
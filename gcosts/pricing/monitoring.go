@@ -15,47 +15,44 @@ limitations under the License.
 */
 package pricing
 
-import (
-	"github.com/pterm/pterm"
-	"os"
-)
-
 // Google Cloud Monitoring data
 
-func CostMonitoringDataMiB0_100000(pricingYml StructPricing, inputRegion string) Cost {
+func CostMonitoringDataMiB0_100000(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Monitoring.Data.Cost.MiB0_100000[inputRegion]
-	if ok {
-		pterm.Success.Printf("Google Cloud Monitoring data (0-100,000 MiB) in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Google Cloud Monitoring data (0-100,000 MiB) in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Google Cloud Monitoring data (0-100,000 MiB)", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostMonitoringDataMiB0_100000_250000(pricingYml StructPricing, inputRegion string) Cost {
+func CostMonitoringDataMiB0_100000_250000(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Monitoring.Data.Cost.MiB0_100000_250000[inputRegion]
-	if ok {
-		pterm.Success.Printf("Google Cloud Monitoring data (100,000-250,000 MiB) in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Google Cloud Monitoring data (100,000-250,000 MiB) in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Google Cloud Monitoring data (100,000-250,000 MiB)", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostMonitoringDataMiB0_250000n(pricingYml StructPricing, inputRegion string) Cost {
+func CostMonitoringDataMiB0_250000n(pricingYml StructPricing, inputRegion string) (Cost, error) {
 	cost, ok := pricingYml.Monitoring.Data.Cost.MiB0_250000n[inputRegion]
-	if ok {
-		pterm.Success.Printf("Google Cloud Monitoring data (250,000n MiB) in region '%s' found.\n", inputRegion)
-	} else {
-		pterm.Error.Printf("Google Cloud Monitoring data (250,000n MiB) in region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Google Cloud Monitoring data (250,000n MiB)", Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func returnMonitoringName(defaultName string, inputName string) string {
+// CostMonitoringDataTiers returns the tiers of Google Cloud Monitoring data (MiB)
+func CostMonitoringDataTiers(pricingYml StructPricing, inputRegion string) ([]Tier, error) {
+	return returnTiers(pricingYml.Monitoring.Data.Tiers, inputRegion, func() ([]Tier, error) {
+		return returnRangeTiers(pricingYml, inputRegion, []float32{0, 100000, 250000},
+			CostMonitoringDataMiB0_100000,
+			CostMonitoringDataMiB0_100000_250000,
+			CostMonitoringDataMiB0_250000n,
+		)
+	})
+}
+
+func (e *Estimate) returnMonitoringName(defaultName string, inputName string) string {
 	var name string
 	if len(defaultName) > 0 {
 		name = defaultName
@@ -65,41 +62,30 @@ func returnMonitoringName(defaultName string, inputName string) string {
 	if len(inputName) > 0 {
 		name = inputName
 	}
-	pterm.Info.Printf("Monitoring name: '%s'\n", name)
+	e.info("Monitoring name: '%s'", name)
 	return name
 }
 
-func CalcMonitoring(pricingYml StructPricing, inputName string, inputData float32, inputRegion string, inputDiscount float32) float32 {
-	name := returnMonitoringName("", inputName)
+func (e *Estimate) CalcMonitoring(inputName string, inputData float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnMonitoringName("", inputName)
 	discount, discountText := returnDiscount(inputDiscount)
-	var range1 float32 = 100000 // 0-100000 MiB
-	var range2 float32 = 250000 // 100000-250000 MiB
 	var price float32
+	var tiers []Tier
 	if inputData > 0 {
-		// 0-100000 MiB
-		month1 := Month(CostMonitoringDataMiB0_100000(pricingYml, inputRegion))
-		monthRange1 := range1 * month1
-		// 100000-250000 MiB
-		month2 := Month(CostMonitoringDataMiB0_100000_250000(pricingYml, inputRegion))
-		monthRange2 := (range2 - range1) * month2
-		// 250000n MiB
-		month3 := Month(CostMonitoringDataMiB0_250000n(pricingYml, inputRegion))
-		if inputData > range2 {
-			price = (inputData - range2) * month3
-			price = price + monthRange2 + monthRange1
-		} else if inputData > range1 {
-			price = (inputData - range1) * month2
-			price = price + monthRange1
-		} else {
-			price = inputData * month1
+		var err error
+		tiers, err = CostMonitoringDataTiers(e.Pricing, inputRegion)
+		if err != nil {
+			return 0, err
 		}
+		price = TieredPrice(tiers, inputData)
 		price = price * discount
-		pterm.Info.Printf("Price '%s' %.2f MiB data per month: $%.2f %s\n", name, inputData, price, discountText)
+		e.info("Price '%s' %.2f MiB data per month: $%.2f %s", name, inputData, price, discountText)
 	}
-	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:     File,
-			Project:  Project,
+	// Usage within the free tier is added as well, AggregateTiers prices it on the total usage of all line items
+	if len(tiers) > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
 			Name:     name,
 			Data:     inputData,
 			Region:   inputRegion,
@@ -107,7 +93,10 @@ func CalcMonitoring(pricingYml StructPricing, inputName string, inputData float3
 			Type:     "data",
 			Discount: discount,
 			Cost:     price,
+
+			tiers:          tiers,
+			billingAccount: e.BillingAccount,
 		})
 	}
-	return price
+	return price, nil
 }
@@ -18,14 +18,12 @@ package pricing
 import (
 	"os"
 
-	"github.com/pterm/pterm"
 	"gopkg.in/yaml.v3"
 )
 
-var CostSum float32
-
 type Region struct {
-	Location string
+	Location  string
+	Continent string // For the inter-region egress
 }
 
 type DualRegion struct {
@@ -44,17 +42,125 @@ type Retrieval struct {
 	Cost map[string]Cost
 }
 
-type Storage struct {
-	Type string
+// Operations are the prices per 1,000 Class A and Class B operations of a storage class
+type Operations struct {
+	ClassA map[string]Cost `yaml:"class-a"`
+	ClassB map[string]Cost `yaml:"class-b"`
+}
+
+// EarlyDeletion is the fee for data deleted before the minimum storage duration
+type EarlyDeletion struct {
+	Days float32 // Minimum storage duration in days
 	Cost map[string]Cost
 }
 
+// Replication is the fee per GiB replicated to a dual-region or multi-region
+type Replication struct {
+	Cost map[string]Cost
+}
+
+type Storage struct {
+	Type       string
+	Partition  float32     // GiB per partition of Local SSDs
+	Iops       Provisioned // Provisioned IOPS
+	Throughput Provisioned // Provisioned throughput in MiB/s
+	Cost       map[string]Cost
+}
+
+// Provisioned is the performance (IOPS or throughput) that can be provisioned for a disk type
+type Provisioned struct {
+	Min      float32
+	Max      float32 // 0 if the disk type does not support provisioning
+	Included float32 // Included in the price of the capacity
+	Cost     map[string]Cost
+}
+
 type Instance struct {
 	Cpu  float32
 	Ram  float32
 	Cost map[string]Cost
 }
 
+// Gpu is a GPU (accelerator) that can be attached to an instance
+type Gpu struct {
+	Regions []string // Regions in which the GPU is available
+	Cost    map[string]Cost
+}
+
+// Custom are the prices per vCPU and per GiB memory of the custom machine types of a machine family
+type Custom struct {
+	Cpu struct {
+		Cost map[string]Cost
+	}
+	Ram struct {
+		Cost map[string]Cost
+	}
+	RamExtended struct {
+		Cost map[string]Cost
+	} `yaml:"ram-extended"`
+}
+
+// SqlEdition are the prices per vCPU and per GiB memory of a Cloud SQL edition
+type SqlEdition struct {
+	Cpu struct {
+		Cost map[string]Cost
+	}
+	Ram struct {
+		Cost map[string]Cost
+	}
+}
+
+// SqlTier is a shared-core Cloud SQL tier
+type SqlTier struct {
+	Cpu  float32
+	Ram  float32
+	Cost map[string]Cost
+}
+
+// SqlLicense is the price per vCPU of a SQL Server edition
+type SqlLicense struct {
+	Cost map[string]Cost
+}
+
+// SqlStorage is the price per GiB of the storage (ssd, hdd) and backups
+type SqlStorage struct {
+	Cost map[string]Cost
+}
+
+// BigqueryPrice is a BigQuery price with the free usage per month
+type BigqueryPrice struct {
+	Free float32 // Free TiB scanned or GiB storage per month
+	Cost map[string]Cost
+}
+
+// ServerlessResource is the price of a Cloud Run resource with the free usage per month
+type ServerlessResource struct {
+	Free float32 // Free vCPU-seconds, GiB-seconds or requests per month
+	Cost map[string]Cost
+}
+
+// ServerlessBilling are the prices of a Cloud Run billing model (request-based or instance-based)
+type ServerlessBilling struct {
+	Cpu      ServerlessResource // per vCPU and hour
+	Ram      ServerlessResource // per GiB and hour
+	Requests ServerlessResource // per 1 million requests (request-based billing)
+	IdleCpu  ServerlessResource `yaml:"idle-cpu"` // Idle minimum instances per vCPU and hour (request-based billing)
+	IdleRam  ServerlessResource `yaml:"idle-ram"` // Idle minimum instances per GiB and hour (request-based billing)
+}
+
+// LoadBalancer are the prices of a Cloud Load Balancing type
+type LoadBalancer struct {
+	Rule struct { // Minimum service charge including the first forwarding rules
+		Cost map[string]Cost
+	}
+	RuleAdditional struct { // Each additional forwarding rule
+		Cost map[string]Cost
+	} `yaml:"rule-additional"`
+	Data struct { // Data processed per GiB
+		Cost map[string]Cost
+	}
+}
+
 type License struct {
 	Cost map[string]Cost
 }
@@ -85,16 +191,53 @@ type StructPricing struct {
 				MiB0_100000_250000 map[string]Cost `yaml:"100000-250000"`
 				MiB0_250000n       map[string]Cost `yaml:"250000n"`
 			}
+			Tiers map[string][]Tier
 		}
 	}
 	Storage struct {
-		Bucket    map[string]Bucket
-		Retrieval map[string]Retrieval
+		Bucket        map[string]Bucket
+		Retrieval     map[string]Retrieval
+		Operations    map[string]Operations
+		EarlyDeletion map[string]EarlyDeletion `yaml:"early-deletion"`
+		Replication   map[string]Replication
+	}
+	Sql struct {
+		Edition map[string]SqlEdition
+		Tier    map[string]SqlTier
+		License map[string]SqlLicense
+		Storage map[string]SqlStorage
+	}
+	Serverless map[string]ServerlessBilling
+	Bigquery   struct {
+		Analysis  BigqueryPrice            // On-demand per TiB scanned
+		Edition   map[string]BigqueryPrice // Capacity per slot and hour
+		Storage   map[string]BigqueryPrice // Active and long-term logical and physical storage per GiB
+		Streaming BigqueryPrice            // Streaming inserts per GiB
+	}
+	Gke struct {
+		// Cluster management fee
+		Cluster struct {
+			Cost map[string]Cost
+		}
+		// Prices per vCPU, GiB memory and GiB ephemeral storage of the Autopilot pod requests
+		Autopilot struct {
+			Cpu struct {
+				Cost map[string]Cost
+			}
+			Ram struct {
+				Cost map[string]Cost
+			}
+			Storage struct {
+				Cost map[string]Cost
+			}
+		}
 	}
 	Compute struct {
 		Storage  map[string]Storage
 		Instance map[string]Instance
 		License  map[string]License
+		Gpu      map[string]Gpu
+		Custom   map[string]Custom
 		Network  struct {
 			Ip struct {
 				Unused struct {
@@ -104,6 +247,7 @@ type StructPricing struct {
 					Cost map[string]Cost
 				}
 			}
+			Lb  map[string]LoadBalancer
 			Vpn struct {
 				Tunnel struct {
 					Cost map[string]Cost
@@ -119,6 +263,12 @@ type StructPricing struct {
 			}
 			Traffic struct {
 				Egress struct {
+					InterZone struct { // Per GiB between zones in the region
+						Cost map[string]Cost
+					} `yaml:"inter-zone"`
+					InterRegion struct { // Per GiB from the continent of the source to the continent of the destination
+						Cost map[string]map[string]Cost
+					} `yaml:"inter-region"`
 					Internet struct {
 						China struct {
 							Cost struct {
@@ -126,6 +276,7 @@ type StructPricing struct {
 								TiB1_10 map[string]Cost `yaml:"1-10"`
 								TiB10n  map[string]Cost `yaml:"10n"`
 							}
+							Tiers map[string][]Tier
 						}
 						Australia struct {
 							Cost struct {
@@ -133,12 +284,14 @@ type StructPricing struct {
 								TiB1_10 map[string]Cost `yaml:"1-10"`
 								TiB10n  map[string]Cost `yaml:"10n"`
 							}
+							Tiers map[string][]Tier
 						}
 						Cost struct {
 							TiB0_1  map[string]Cost `yaml:"0-1"`
 							TiB1_10 map[string]Cost `yaml:"1-10"`
 							TiB10n  map[string]Cost `yaml:"10n"`
 						}
+						Tiers map[string][]Tier
 					}
 				}
 			}
@@ -146,25 +299,13 @@ type StructPricing struct {
 	}
 }
 
-func readPricingYmlFile(file string) []byte {
-	pterm.Info.Printf("YAML file with GCP pricing informations: '%s'\n", file)
-	f, err := os.ReadFile(file)
-	if err != nil {
-		pterm.Error.Println(err)
-		os.Exit(9)
-	}
-	return f
-}
-
-func Yml(file string) StructPricing {
-	filecontent := readPricingYmlFile(file)
-
+func Yml(file string) (StructPricing, error) {
 	s := StructPricing{}
-	err := yaml.Unmarshal([]byte(filecontent), &s)
+	filecontent, err := os.ReadFile(file)
 	if err != nil {
-		pterm.Error.Println(err)
-		os.Exit(8)
+		return s, err
 	}
 
-	return s
+	err = yaml.Unmarshal([]byte(filecontent), &s)
+	return s, err
 }
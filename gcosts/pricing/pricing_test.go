@@ -0,0 +1,38 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"math"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// testPricing returns the pricing information of a YAML snippet
+func testPricing(t *testing.T, content string) StructPricing {
+	t.Helper()
+	s := StructPricing{}
+	if err := yaml.Unmarshal([]byte(content), &s); err != nil {
+		t.Fatalf("pricing YAML could not be processed: %v", err)
+	}
+	return s
+}
+
+// testEqual returns true if the prices are equal to the cent fraction
+func testEqual(a float32, b float32) bool {
+	return math.Abs(float64(a)-float64(b)) < 0.0001
+}
@@ -15,34 +15,17 @@ limitations under the License.
 */
 package pricing
 
-import (
-	"os"
-
-	"github.com/pterm/pterm"
-)
-
-func CheckRegion(pricingYml StructPricing, inputRegion string) bool {
+func CheckRegion(pricingYml StructPricing, inputRegion string) error {
 	_, regionOk := pricingYml.Region[inputRegion]
 	_, dualRegionOk := pricingYml.DualRegion[inputRegion]
 	_, multiRegionOk := pricingYml.MultiRegion[inputRegion]
-	found := false
-	if regionOk {
-		pterm.Success.Printf("Google Cloud region '%s' found.\n", inputRegion)
-		found = true
-	} else if dualRegionOk {
-		pterm.Success.Printf("Google Cloud dual region '%s' found.\n", inputRegion)
-		found = true
-	} else if multiRegionOk {
-		pterm.Success.Printf("Google Cloud multi region '%s' found.\n", inputRegion)
-		found = true
-	} else {
-		pterm.Error.Printf("Google Cloud region '%s' not found!\n", inputRegion)
-		os.Exit(1)
+	if !regionOk && !dualRegionOk && !multiRegionOk {
+		return &RegionError{Region: inputRegion}
 	}
-	return found
+	return nil
 }
 
-func ReturnRegion(pricingYml StructPricing, defaultRegion string, inputRegion string) string {
+func ReturnRegion(pricingYml StructPricing, defaultRegion string, inputRegion string) (string, error) {
 	var region string
 	if len(defaultRegion) > 0 {
 		region = defaultRegion
@@ -50,9 +33,10 @@ func ReturnRegion(pricingYml StructPricing, defaultRegion string, inputRegion st
 		region = "us-central1"
 	}
 	if len(inputRegion) > 0 {
-		CheckRegion(pricingYml, inputRegion)
+		if err := CheckRegion(pricingYml, inputRegion); err != nil {
+			return region, err
+		}
 		region = inputRegion
 	}
-	pterm.Info.Printf("Google Cloud region: '%s'\n", region)
-	return region
+	return region, nil
 }
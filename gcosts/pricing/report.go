@@ -0,0 +1,88 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+// Report is the machine-readable result of an estimate
+type Report struct {
+	About       ReportAbout       `json:"about" yaml:"about"`
+	Total       float32           `json:"total" yaml:"total"`
+	Files       []Subtotal        `json:"files" yaml:"files"`
+	Projects    []Subtotal        `json:"projects" yaml:"projects"`
+	LineItems   []LineItem        `json:"line-items" yaml:"line-items"`
+	Warnings    []Diagnostic      `json:"warnings" yaml:"warnings"`
+	Budgets     []BudgetCheck     `json:"budgets" yaml:"budgets"`
+	Commitments []CommitmentUsage `json:"commitments" yaml:"commitments"`
+}
+
+// ReportAbout contains the metadata of the pricing information used for the estimate
+type ReportAbout struct {
+	Generated string `json:"generated" yaml:"generated"`
+	Timestamp string `json:"timestamp" yaml:"timestamp"`
+}
+
+// Subtotal is the sum of the costs of all line items of one usage file or project
+type Subtotal struct {
+	Name string  `json:"name" yaml:"name"`
+	Cost float32 `json:"cost" yaml:"cost"`
+}
+
+// Report returns the line items of the estimate with totals, subtotals, the checked budgets and the applied commitments.
+// Subtotals are sorted in the order in which the usage files and projects were calculated.
+func (e *Estimate) Report(warnings []Diagnostic, budgets []BudgetCheck, commitments []CommitmentUsage) Report {
+	lineItems := e.LineItems
+	if lineItems == nil {
+		lineItems = []LineItem{}
+	}
+	if warnings == nil {
+		warnings = []Diagnostic{}
+	}
+	if budgets == nil {
+		budgets = []BudgetCheck{}
+	}
+	if commitments == nil {
+		commitments = []CommitmentUsage{}
+	}
+	return Report{
+		About: ReportAbout{
+			Generated: e.Pricing.About.Generated,
+			Timestamp: e.Pricing.About.Timestamp,
+		},
+		Total:       e.Total(),
+		Files:       subtotals(lineItems, func(lineItem LineItem) string { return lineItem.File }),
+		Projects:    subtotals(lineItems, func(lineItem LineItem) string { return lineItem.Project }),
+		LineItems:   lineItems,
+		Warnings:    warnings,
+		Budgets:     budgets,
+		Commitments: commitments,
+	}
+}
+
+// subtotals sums the costs of the line items grouped by the returned key
+func subtotals(lineItems []LineItem, key func(lineItem LineItem) string) []Subtotal {
+	sums := []Subtotal{}
+	index := map[string]int{}
+	for _, lineItem := range lineItems {
+		name := key(lineItem)
+		i, ok := index[name]
+		if !ok {
+			i = len(sums)
+			index[name] = i
+			sums = append(sums, Subtotal{Name: name})
+		}
+		sums[i].Cost += lineItem.Cost
+	}
+	return sums
+}
@@ -0,0 +1,219 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+)
+
+// Cloud Run services and Cloud Run functions
+
+// Billing models of Cloud Run
+const (
+	ServerlessBillingRequest  = "request"  // CPU is only allocated during requests
+	ServerlessBillingInstance = "instance" // CPU is always allocated
+)
+
+// Defaults of a Cloud Run service
+const (
+	ServerlessCpu         float32 = 1   // vCPUs per instance
+	ServerlessMemory      float32 = 0.5 // GiB per instance
+	ServerlessConcurrency         = 80  // Concurrent requests per instance
+)
+
+// ServerlessRequests is the number of requests of the price per requests
+const ServerlessRequests float32 = 1000000
+
+// SecondsMonth are the seconds of one month
+const SecondsMonth float32 = HoursMonth * 3600
+
+// CheckServerlessBilling returns an error if the billing model is unknown
+func CheckServerlessBilling(pricingYml StructPricing, inputBilling string) (ServerlessBilling, error) {
+	billing, ok := pricingYml.Serverless[inputBilling]
+	if !ok {
+		return ServerlessBilling{}, &ResourceError{Resource: "Cloud Run billing", Name: inputBilling}
+	}
+	return billing, nil
+}
+
+func returnServerlessCost(resource ServerlessResource, description string, inputBilling string, inputRegion string) (Cost, error) {
+	cost, ok := resource.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: fmt.Sprintf("Cloud Run %s (%s-based billing)", description, inputBilling), Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostServerlessCpu(pricingYml StructPricing, inputBilling string, inputRegion string) (Cost, error) {
+	billing, err := CheckServerlessBilling(pricingYml, inputBilling)
+	if err != nil {
+		return Cost{}, err
+	}
+	return returnServerlessCost(billing.Cpu, "vCPU", inputBilling, inputRegion)
+}
+
+func CostServerlessRam(pricingYml StructPricing, inputBilling string, inputRegion string) (Cost, error) {
+	billing, err := CheckServerlessBilling(pricingYml, inputBilling)
+	if err != nil {
+		return Cost{}, err
+	}
+	return returnServerlessCost(billing.Ram, "memory", inputBilling, inputRegion)
+}
+
+func CostServerlessRequests(pricingYml StructPricing, inputBilling string, inputRegion string) (Cost, error) {
+	billing, err := CheckServerlessBilling(pricingYml, inputBilling)
+	if err != nil {
+		return Cost{}, err
+	}
+	return returnServerlessCost(billing.Requests, "requests", inputBilling, inputRegion)
+}
+
+func CostServerlessIdleCpu(pricingYml StructPricing, inputBilling string, inputRegion string) (Cost, error) {
+	billing, err := CheckServerlessBilling(pricingYml, inputBilling)
+	if err != nil {
+		return Cost{}, err
+	}
+	return returnServerlessCost(billing.IdleCpu, "idle vCPU", inputBilling, inputRegion)
+}
+
+func CostServerlessIdleRam(pricingYml StructPricing, inputBilling string, inputRegion string) (Cost, error) {
+	billing, err := CheckServerlessBilling(pricingYml, inputBilling)
+	if err != nil {
+		return Cost{}, err
+	}
+	return returnServerlessCost(billing.IdleRam, "idle memory", inputBilling, inputRegion)
+}
+
+// ServerlessSeconds returns the seconds per month the instances are active and idle.
+// The instances are active while they process the requests (requests * duration / concurrency).
+// Minimum instances are idle if they do not process requests, with instance-based billing they are always active.
+func ServerlessSeconds(inputBilling string, inputRequests float32, inputDuration float32, inputConcurrency int, inputMinInstances int) (float32, float32) {
+	concurrency := inputConcurrency
+	if concurrency <= 0 {
+		concurrency = ServerlessConcurrency
+	}
+	active := inputRequests * (inputDuration / 1000) / float32(concurrency)
+	minimum := float32(inputMinInstances) * SecondsMonth
+	if inputBilling == ServerlessBillingInstance {
+		return max(active, minimum), 0
+	}
+	return active, max(minimum-active, 0)
+}
+
+func (e *Estimate) returnServerlessName(defaultName string, inputName string) string {
+	var name string
+	if len(defaultName) > 0 {
+		name = defaultName
+	} else {
+		name = "default-serverless-name"
+	}
+	if len(inputName) > 0 {
+		name = inputName
+	}
+	e.info("Cloud Run service name: '%s'", name)
+	return name
+}
+
+// ReturnServerlessBilling returns the billing model with the default request-based billing
+func ReturnServerlessBilling(inputBilling string) string {
+	if len(inputBilling) > 0 {
+		return inputBilling
+	}
+	return ServerlessBillingRequest
+}
+
+// serverlessUsage is the usage of a Cloud Run resource per month
+type serverlessUsage struct {
+	resource string  // Type of the line item
+	data     float32 // vCPU-seconds, GiB-seconds or requests
+	unit     float32 // Price per unit is the price per hour or month divided by the unit
+	free     float32 // Free usage per month
+	cost     func(StructPricing, string, string) (Cost, error)
+	month    bool // Price per month (requests) instead of per hour
+}
+
+// CalcServerless calculates the costs of a Cloud Run service or function with the requests per month.
+// The free usage is the first tier of the vCPU, memory and requests line items.
+// Line items within the free usage are kept for the aggregation of the tiered usage and removed by AggregateTiers.
+func (e *Estimate) CalcServerless(inputName string, inputBilling string, inputCpu float32, inputMemory float32, inputRequests float32, inputDuration float32, inputConcurrency int, inputMinInstances int, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnServerlessName("", inputName)
+	billing := ReturnServerlessBilling(inputBilling)
+	if _, err := CheckServerlessBilling(e.Pricing, billing); err != nil {
+		return 0, err
+	}
+	cpu := inputCpu
+	if !(cpu > 0) {
+		cpu = ServerlessCpu
+	}
+	memory := inputMemory
+	if !(memory > 0) {
+		memory = ServerlessMemory
+	}
+	e.info("Cloud Run %s-based billing with %.2f vCPU and %.2f GiB memory per instance", billing, cpu, memory)
+	discount, discountText := returnDiscount(inputDiscount)
+	active, idle := ServerlessSeconds(billing, inputRequests, inputDuration, inputConcurrency, inputMinInstances)
+	e.info("Cloud Run instances active %.0f seconds and idle %.0f seconds per month", active, idle)
+
+	prices := e.Pricing.Serverless[billing]
+	usages := []serverlessUsage{
+		{billing + "-cpu", active * cpu, 3600, prices.Cpu.Free, CostServerlessCpu, false},
+		{billing + "-ram", active * memory, 3600, prices.Ram.Free, CostServerlessRam, false},
+	}
+	if billing == ServerlessBillingRequest {
+		usages = append(usages,
+			serverlessUsage{"requests", inputRequests, ServerlessRequests, prices.Requests.Free, CostServerlessRequests, true},
+			serverlessUsage{billing + "-idle-cpu", idle * cpu, 3600, 0, CostServerlessIdleCpu, false},
+			serverlessUsage{billing + "-idle-ram", idle * memory, 3600, 0, CostServerlessIdleRam, false},
+		)
+	}
+
+	var price float32
+	for _, usage := range usages {
+		if !(usage.data > 0) {
+			continue
+		}
+		var unitPrice float32
+		var err error
+		if usage.month {
+			unitPrice, err = costMonth(usage.cost(e.Pricing, billing, inputRegion))
+		} else {
+			unitPrice, err = costHour(usage.cost(e.Pricing, billing, inputRegion))
+		}
+		if err != nil {
+			return 0, err
+		}
+		tiers := returnFreeTiers(usage.free, unitPrice/usage.unit)
+		priceUsage := TieredPrice(tiers, usage.data) * discount
+		e.info("Price '%s' Cloud Run '%s' %.0f (%.0f free) per month: $%.2f %s", name, usage.resource, usage.data, usage.free, priceUsage, discountText)
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Name:     name,
+			Type:     usage.resource,
+			Data:     usage.data,
+			Region:   inputRegion,
+			Resource: "serverless",
+			Discount: discount,
+			Cost:     priceUsage,
+
+			tiers:          tiers,
+			billingAccount: e.BillingAccount,
+			free:           usage.free > 0,
+		})
+		price += priceUsage
+	}
+	return price, nil
+}
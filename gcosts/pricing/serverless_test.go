@@ -0,0 +1,132 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+func TestServerlessSeconds(t *testing.T) {
+	tests := []struct {
+		name         string
+		billing      string
+		requests     float32
+		duration     float32
+		concurrency  int
+		minInstances int
+		wantActive   float32
+		wantIdle     float32
+	}{
+		{"default concurrency", ServerlessBillingRequest, 8000000, 100, 0, 0, 8000000 * 0.1 / ServerlessConcurrency, 0},
+		{"concurrency", ServerlessBillingRequest, 1000000, 200, 10, 0, 1000000 * 0.2 / 10, 0},
+		{"idle minimum instances", ServerlessBillingRequest, 1000000, 200, 10, 1, 20000, SecondsMonth - 20000},
+		{"busy minimum instances", ServerlessBillingRequest, 100000000, 1000, 1, 1, 100000000, 0},
+		{"instance-based minimum instances", ServerlessBillingInstance, 1000000, 200, 10, 2, 2 * SecondsMonth, 0},
+		{"instance-based active", ServerlessBillingInstance, 100000000, 1000, 1, 1, 100000000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, idle := ServerlessSeconds(tt.billing, tt.requests, tt.duration, tt.concurrency, tt.minInstances)
+			if !testEqual(active, tt.wantActive) || !testEqual(idle, tt.wantIdle) {
+				t.Errorf("seconds = %f active and %f idle, want %f and %f", active, idle, tt.wantActive, tt.wantIdle)
+			}
+		})
+	}
+}
+
+func TestCalcServerless(t *testing.T) {
+	pricingYml := testPricing(t, `
+serverless:
+  request:
+    cpu:
+      free: 180000
+      cost:
+        us-central1: {hour: 0.0864}
+    ram:
+      free: 360000
+      cost:
+        us-central1: {hour: 0.009}
+    requests:
+      free: 2000000
+      cost:
+        us-central1: {month: 0.4}
+    idle-cpu:
+      cost:
+        us-central1: {hour: 0.0036}
+    idle-ram:
+      cost:
+        us-central1: {hour: 0.0036}
+`)
+	type service struct {
+		requests float32
+		duration float32 // Milliseconds
+	}
+	tests := []struct {
+		name     string
+		services []service
+		want     map[string]float32 // Total cost by type of the line items
+	}{
+		{
+			name:     "within free usage",
+			services: []service{{requests: 1000000, duration: 100}},
+			want:     map[string]float32{},
+		},
+		{
+			name:     "requests above free usage",
+			services: []service{{requests: 3000000, duration: 100}},
+			want:     map[string]float32{"requests": 1000000 * 0.4 / ServerlessRequests},
+		},
+		{
+			name:     "free usage shared by services",
+			services: []service{{requests: 1500000, duration: 100}, {requests: 1500000, duration: 100}},
+			want:     map[string]float32{"requests": 1000000 * 0.4 / ServerlessRequests},
+		},
+		{
+			// 300000 vCPU-seconds and 150000 GiB-seconds (within free usage)
+			name:     "vCPU above free usage",
+			services: []service{{requests: 8000000, duration: 3000}},
+			want: map[string]float32{
+				"request-cpu": (300000 - 180000) * 0.0864 / 3600,
+				"requests":    6000000 * 0.4 / ServerlessRequests,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			for _, s := range tt.services {
+				if _, err := e.CalcServerless("run", "", 0, 0, s.requests, s.duration, 0, 0, "us-central1", 1); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := e.AggregateTiers(TierScopeTotal); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := map[string]float32{}
+			for _, lineItem := range e.LineItems {
+				got[lineItem.Type] = got[lineItem.Type] + lineItem.Cost
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("line items = %v, want %v", got, tt.want)
+			}
+			for kind, want := range tt.want {
+				if !testEqual(got[kind], want) {
+					t.Errorf("cost of '%s' = %f, want %f", kind, got[kind], want)
+				}
+			}
+		})
+	}
+}
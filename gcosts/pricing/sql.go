@@ -0,0 +1,358 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Cloud SQL
+
+// Editions of Cloud SQL
+const (
+	SqlEditionEnterprise     = "enterprise"
+	SqlEditionEnterprisePlus = "enterprise-plus"
+)
+
+// SqlHighAvailability is the price multiplier of instances and storage with high availability (regional)
+const SqlHighAvailability float32 = 2
+
+// sqlDatabases are the editions of the database engines.
+// The editions of SQL Server are the license editions and run on the Enterprise edition of Cloud SQL.
+var sqlDatabases = map[string][]string{
+	"mysql":     {SqlEditionEnterprise, SqlEditionEnterprisePlus},
+	"postgres":  {SqlEditionEnterprise, SqlEditionEnterprisePlus},
+	"sqlserver": {"standard", "enterprise", "web", "express"},
+}
+
+// SqlMachine is the parsed tier of a Cloud SQL instance
+type SqlMachine struct {
+	Tier   string
+	Cpu    float32 // Number of vCPUs
+	Ram    float32 // Memory in GiB
+	Shared bool    // Shared-core tier with fixed price
+}
+
+var (
+	sqlCustomTierRegexp = regexp.MustCompile(`^db-custom-(\d+)-(\d+)$`)
+	sqlPerfTierRegexp   = regexp.MustCompile(`^db-perf-optimized-N-(\d+)$`)
+	// vCPUs of the Enterprise Plus performance-optimized tiers with 8 GiB memory per vCPU
+	sqlPerfTierCpus = []int{2, 4, 8, 16, 32, 48, 64, 80, 96, 128}
+)
+
+// ReturnSqlDatabase returns the database engine and the edition with defaults (mysql, enterprise or standard)
+func ReturnSqlDatabase(inputDatabase string, inputEdition string) (string, string) {
+	database := "mysql"
+	if len(inputDatabase) > 0 {
+		database = inputDatabase
+	}
+	edition := inputEdition
+	if len(edition) == 0 {
+		edition = SqlEditionEnterprise
+		if database == "sqlserver" {
+			edition = "standard"
+		}
+	}
+	return database, edition
+}
+
+// ReturnSqlEdition returns the Cloud SQL edition of the database engine and edition
+func ReturnSqlEdition(inputDatabase string, inputEdition string) string {
+	if inputDatabase == "sqlserver" {
+		return SqlEditionEnterprise
+	}
+	return inputEdition
+}
+
+// CheckSqlDatabase returns an error if the database engine or the edition is unknown
+func CheckSqlDatabase(inputDatabase string, inputEdition string) error {
+	editions, ok := sqlDatabases[inputDatabase]
+	if !ok {
+		return &ResourceError{Resource: "Cloud SQL database engine", Name: inputDatabase}
+	}
+	if !slices.Contains(editions, inputEdition) {
+		return &ResourceError{Resource: fmt.Sprintf("Cloud SQL %s edition", inputDatabase), Name: inputEdition}
+	}
+	return nil
+}
+
+// CheckSqlTier parses the tier and checks the limits of the database engine and edition
+func CheckSqlTier(pricingYml StructPricing, inputDatabase string, inputEdition string, inputTier string) (SqlMachine, error) {
+	edition := ReturnSqlEdition(inputDatabase, inputEdition)
+	if tier, ok := pricingYml.Sql.Tier[inputTier]; ok {
+		if edition != SqlEditionEnterprise || inputDatabase == "sqlserver" {
+			return SqlMachine{}, &MachineTypeError{Name: inputTier, Reason: "shared-core tiers are only available for MySQL and PostgreSQL with the Enterprise edition"}
+		}
+		return SqlMachine{Tier: inputTier, Cpu: tier.Cpu, Ram: tier.Ram, Shared: true}, nil
+	}
+	if match := sqlCustomTierRegexp.FindStringSubmatch(inputTier); match != nil {
+		if edition != SqlEditionEnterprise {
+			return SqlMachine{}, &MachineTypeError{Name: inputTier, Reason: "custom tiers are only available with the Enterprise edition"}
+		}
+		cpu, err := strconv.Atoi(match[1])
+		if err != nil || !(cpu == 1 || (cpu%2 == 0 && cpu >= 2 && cpu <= 96)) {
+			return SqlMachine{}, &MachineTypeError{Name: inputTier, Reason: "number of vCPUs must be 1 or a multiple of 2 up to 96"}
+		}
+		mib, err := strconv.Atoi(match[2])
+		if err != nil || mib%256 != 0 {
+			return SqlMachine{}, &MachineTypeError{Name: inputTier, Reason: "memory must be a multiple of 256 MiB"}
+		}
+		ram := float32(mib) / 1024
+		if ram < 0.9*float32(cpu) || ram > 6.5*float32(cpu) {
+			return SqlMachine{}, &MachineTypeError{Name: inputTier, Reason: "memory must be between 0.9 and 6.5 GiB per vCPU"}
+		}
+		return SqlMachine{Tier: inputTier, Cpu: float32(cpu), Ram: ram}, nil
+	}
+	if match := sqlPerfTierRegexp.FindStringSubmatch(inputTier); match != nil {
+		if edition != SqlEditionEnterprisePlus {
+			return SqlMachine{}, &MachineTypeError{Name: inputTier, Reason: "performance-optimized tiers are only available with the Enterprise Plus edition"}
+		}
+		cpu, err := strconv.Atoi(match[1])
+		if err != nil || !slices.Contains(sqlPerfTierCpus, cpu) {
+			return SqlMachine{}, &MachineTypeError{Name: inputTier, Reason: fmt.Sprintf("number of vCPUs must be one of %v", sqlPerfTierCpus)}
+		}
+		return SqlMachine{Tier: inputTier, Cpu: float32(cpu), Ram: float32(cpu) * 8}, nil
+	}
+	return SqlMachine{}, &ResourceError{Resource: "Cloud SQL tier", Name: inputTier}
+}
+
+// CostSqlInstance calculates the costs of the tier from the price per vCPU and per GiB memory of the edition
+func CostSqlInstance(pricingYml StructPricing, inputDatabase string, inputEdition string, inputTier string, inputRegion string) (Cost, error) {
+	machine, err := CheckSqlTier(pricingYml, inputDatabase, inputEdition, inputTier)
+	if err != nil {
+		return Cost{}, err
+	}
+	if machine.Shared {
+		cost, ok := pricingYml.Sql.Tier[inputTier].Cost[inputRegion]
+		if !ok {
+			return Cost{}, &ResourceError{Resource: "Cloud SQL tier", Name: inputTier, Region: inputRegion}
+		}
+		return cost, nil
+	}
+	edition := ReturnSqlEdition(inputDatabase, inputEdition)
+	resource, ok := pricingYml.Sql.Edition[edition]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Cloud SQL edition", Name: edition}
+	}
+	cpu, ok := resource.Cpu.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Cloud SQL tier", Name: inputTier, Region: inputRegion}
+	}
+	ram, ok := resource.Ram.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Cloud SQL tier", Name: inputTier, Region: inputRegion}
+	}
+	return Cost{
+		Hour:    returnSqlPrice(machine, cpu.Hour, ram.Hour),
+		Month:   returnSqlPrice(machine, cpu.Month, ram.Month),
+		Month1Y: returnSqlPrice(machine, cpu.Month1Y, ram.Month1Y),
+		Month3Y: returnSqlPrice(machine, cpu.Month3Y, ram.Month3Y),
+	}, nil
+}
+
+// returnSqlPrice returns the price of all vCPUs and the memory.
+// Returns 0 if one of the prices is missing.
+func returnSqlPrice(machine SqlMachine, inputCpu float32, inputRam float32) float32 {
+	if !(inputCpu > 0) || !(inputRam > 0) {
+		return 0
+	}
+	return inputCpu*machine.Cpu + inputRam*machine.Ram
+}
+
+func CostSqlLicense(pricingYml StructPricing, inputEdition string, inputRegion string) (Cost, error) {
+	license, ok := pricingYml.Sql.License[inputEdition]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Cloud SQL SQL Server license", Name: inputEdition}
+	}
+	cost, ok := license.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Cloud SQL SQL Server license", Name: inputEdition, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostSqlStorage(pricingYml StructPricing, inputStorageType string, inputRegion string) (Cost, error) {
+	storage, ok := pricingYml.Sql.Storage[inputStorageType]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Cloud SQL storage type", Name: inputStorageType}
+	}
+	cost, ok := storage.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "Cloud SQL storage type", Name: inputStorageType, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func (e *Estimate) returnSqlInstanceName(defaultName string, inputName string) string {
+	var name string
+	if len(defaultName) > 0 {
+		name = defaultName
+	} else {
+		name = "default-sql-instance-name"
+	}
+	if len(inputName) > 0 {
+		name = inputName
+	}
+	e.info("Cloud SQL instance name: '%s'", name)
+	return name
+}
+
+// returnSqlHighAvailability returns the price multiplier for high availability
+func (e *Estimate) returnSqlHighAvailability(inputValue bool) float32 {
+	if inputValue {
+		e.info("Cloud SQL high availability: yes (regional)")
+		return SqlHighAvailability
+	}
+	return 1
+}
+
+// CalcSqlInstance calculates the costs of the vCPUs and the memory of the instance
+// and the SQL Server license per vCPU.
+// Commitments do not cover the license.
+func (e *Estimate) CalcSqlInstance(inputName string, inputDatabase string, inputEdition string, inputTier string, inputHighAvailability bool, inputRegion string, inputDiscount float32, inputCommitment int) (float32, error) {
+	name := e.returnSqlInstanceName("", inputName)
+	database, edition := ReturnSqlDatabase(inputDatabase, inputEdition)
+	if err := CheckSqlDatabase(database, edition); err != nil {
+		return 0, err
+	}
+	e.info("Cloud SQL database '%s' edition '%s'", database, edition)
+	commitment := e.returnComputeInstanceCommitment(inputCommitment)
+	ha := e.returnSqlHighAvailability(inputHighAvailability)
+	discount, discountText := returnDiscount(inputDiscount)
+	machine, err := CheckSqlTier(e.Pricing, database, edition, inputTier)
+	if err != nil {
+		return 0, err
+	}
+	cost, err := CostSqlInstance(e.Pricing, database, edition, inputTier, inputRegion)
+	if err != nil {
+		return 0, err
+	}
+	e.success("Cloud SQL tier '%s' in region '%s' found.", inputTier, inputRegion)
+	var month float32
+	switch commitment {
+	case 1:
+		month, err = e.month1Y(cost)
+	case 3:
+		month, err = e.month3Y(cost)
+	default:
+		month, err = Month(cost)
+	}
+	if err != nil {
+		return 0, err
+	}
+	price := month * ha * discount
+	e.info("Price '%s' Cloud SQL instance per month: $%.2f %s", name, price, discountText)
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:       e.File,
+			Project:    e.Project,
+			Name:       name,
+			Type:       inputTier,
+			Region:     inputRegion,
+			Resource:   "sql",
+			Commitment: commitment,
+			Discount:   discount,
+			Cost:       price,
+		})
+	}
+	// SQL Server license per vCPU, Express edition is free
+	if database == "sqlserver" && edition != "express" {
+		month, err := costMonth(CostSqlLicense(e.Pricing, edition, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("Cloud SQL SQL Server license '%s' in region '%s' found.", edition, inputRegion)
+		license := (month * machine.Cpu) * discount
+		e.info("Price '%s' SQL Server license for %.0f vCPUs per month: $%.2f %s", name, machine.Cpu, license, discountText)
+		if license > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
+				Name:     name,
+				Type:     "sqlserver-" + edition,
+				Data:     machine.Cpu,
+				Region:   inputRegion,
+				Resource: "sql-license",
+				Discount: discount,
+				Cost:     license,
+			})
+		}
+		price += license
+	}
+	return price, nil
+}
+
+// CalcSqlStorage calculates the storage (ssd or hdd) and the backups of the instance
+func (e *Estimate) CalcSqlStorage(inputName string, inputStorageType string, inputStorageData float32, inputBackup float32, inputHighAvailability bool, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnSqlInstanceName("", inputName)
+	storageType := "ssd"
+	if len(inputStorageType) > 0 {
+		storageType = inputStorageType
+	}
+	ha := e.returnSqlHighAvailability(inputHighAvailability)
+	discount, discountText := returnDiscount(inputDiscount)
+	var price float32
+	if inputStorageData > 0 {
+		month, err := costMonth(CostSqlStorage(e.Pricing, storageType, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("Cloud SQL storage type '%s' in region '%s' found.", storageType, inputRegion)
+		storage := (month * inputStorageData) * ha * discount
+		e.info("Price '%s' Cloud SQL storage '%.2f' GiB per month: $%.2f %s", name, inputStorageData, storage, discountText)
+		if storage > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
+				Name:     name,
+				Type:     storageType,
+				Data:     inputStorageData,
+				Region:   inputRegion,
+				Resource: "sql-storage",
+				Discount: discount,
+				Cost:     storage,
+			})
+		}
+		price += storage
+	}
+	if inputBackup > 0 {
+		month, err := costMonth(CostSqlStorage(e.Pricing, "backup", inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("Cloud SQL backup in region '%s' found.", inputRegion)
+		backup := (month * inputBackup) * discount
+		e.info("Price '%s' Cloud SQL backup '%.2f' GiB per month: $%.2f %s", name, inputBackup, backup, discountText)
+		if backup > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
+				Name:     name,
+				Type:     "backup",
+				Data:     inputBackup,
+				Region:   inputRegion,
+				Resource: "sql-backup",
+				Discount: discount,
+				Cost:     backup,
+			})
+		}
+		price += backup
+	}
+	return price, nil
+}
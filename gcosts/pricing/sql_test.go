@@ -0,0 +1,181 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+const testSqlPricing = `
+sql:
+  edition:
+    enterprise:
+      cpu:
+        cost:
+          us-central1: {hour: 0.04, month: 30, month_1y: 22, month_3y: 15}
+      ram:
+        cost:
+          us-central1: {hour: 0.007, month: 5, month_1y: 4, month_3y: 2}
+    enterprise-plus:
+      cpu:
+        cost:
+          us-central1: {hour: 0.05, month: 40}
+      ram:
+        cost:
+          us-central1: {hour: 0.009, month: 7}
+  tier:
+    db-f1-micro:
+      cpu: 0.2
+      ram: 0.6
+      cost:
+        us-central1: {hour: 0.01, month: 7.5}
+  license:
+    standard:
+      cost:
+        us-central1: {hour: 0.12, month: 90}
+  storage:
+    ssd:
+      cost:
+        us-central1: {month: 0.2}
+    backup:
+      cost:
+        us-central1: {month: 0.1}
+`
+
+func TestCheckSqlTier(t *testing.T) {
+	pricingYml := testPricing(t, testSqlPricing)
+	tests := []struct {
+		database string
+		edition  string
+		tier     string
+		want     SqlMachine
+		wantErr  bool
+	}{
+		{"mysql", SqlEditionEnterprise, "db-f1-micro", SqlMachine{Tier: "db-f1-micro", Cpu: 0.2, Ram: 0.6, Shared: true}, false},
+		{"mysql", SqlEditionEnterprise, "db-custom-4-16384", SqlMachine{Tier: "db-custom-4-16384", Cpu: 4, Ram: 16}, false},
+		{"postgres", SqlEditionEnterprise, "db-custom-1-3840", SqlMachine{Tier: "db-custom-1-3840", Cpu: 1, Ram: 3.75}, false},
+		{"sqlserver", "standard", "db-custom-2-8192", SqlMachine{Tier: "db-custom-2-8192", Cpu: 2, Ram: 8}, false},
+		{"postgres", SqlEditionEnterprisePlus, "db-perf-optimized-N-8", SqlMachine{Tier: "db-perf-optimized-N-8", Cpu: 8, Ram: 64}, false},
+		{"mysql", SqlEditionEnterprisePlus, "db-f1-micro", SqlMachine{}, true},       // Shared-core only with Enterprise
+		{"sqlserver", "standard", "db-f1-micro", SqlMachine{}, true},                 // Shared-core not with SQL Server
+		{"mysql", SqlEditionEnterprisePlus, "db-custom-4-16384", SqlMachine{}, true}, // Custom only with Enterprise
+		{"mysql", SqlEditionEnterprise, "db-custom-3-12288", SqlMachine{}, true},     // Odd number of vCPUs
+		{"mysql", SqlEditionEnterprise, "db-custom-4-16000", SqlMachine{}, true},     // Not a multiple of 256 MiB
+		{"mysql", SqlEditionEnterprise, "db-custom-4-32768", SqlMachine{}, true},     // More than 6.5 GiB per vCPU
+		{"mysql", SqlEditionEnterprise, "db-perf-optimized-N-8", SqlMachine{}, true}, // Performance-optimized only with Enterprise Plus
+		{"mysql", SqlEditionEnterprisePlus, "db-perf-optimized-N-6", SqlMachine{}, true},
+		{"mysql", SqlEditionEnterprise, "db-n1-standard-1", SqlMachine{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.database+"/"+tt.edition+"/"+tt.tier, func(t *testing.T) {
+			got, err := CheckSqlTier(pricingYml, tt.database, tt.edition, tt.tier)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tier = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostSqlInstance(t *testing.T) {
+	pricingYml := testPricing(t, testSqlPricing)
+	tests := []struct {
+		name     string
+		database string
+		edition  string
+		tier     string
+		region   string
+		want     Cost
+		wantErr  bool
+	}{
+		{"shared-core tier", "mysql", SqlEditionEnterprise, "db-f1-micro", "us-central1", Cost{Hour: 0.01, Month: 7.5}, false},
+		{"custom tier", "mysql", SqlEditionEnterprise, "db-custom-4-16384", "us-central1", Cost{Hour: 4*0.04 + 16*0.007, Month: 4*30 + 16*5, Month1Y: 4*22 + 16*4, Month3Y: 4*15 + 16*2}, false},
+		{"SQL Server on Enterprise edition", "sqlserver", "enterprise", "db-custom-2-8192", "us-central1", Cost{Hour: 2*0.04 + 8*0.007, Month: 2*30 + 8*5, Month1Y: 2*22 + 8*4, Month3Y: 2*15 + 8*2}, false},
+		{"Enterprise Plus without commitments", "postgres", SqlEditionEnterprisePlus, "db-perf-optimized-N-2", "us-central1", Cost{Hour: 2*0.05 + 16*0.009, Month: 2*40 + 16*7}, false},
+		{"missing region", "mysql", SqlEditionEnterprise, "db-custom-4-16384", "europe-west4", Cost{}, true},
+		{"missing region of shared-core tier", "mysql", SqlEditionEnterprise, "db-f1-micro", "europe-west4", Cost{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CostSqlInstance(pricingYml, tt.database, tt.edition, tt.tier, tt.region)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tt.wantErr)
+			}
+			if !testEqual(got.Hour, tt.want.Hour) || !testEqual(got.Month, tt.want.Month) || !testEqual(got.Month1Y, tt.want.Month1Y) || !testEqual(got.Month3Y, tt.want.Month3Y) {
+				t.Errorf("cost = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalcSqlInstance(t *testing.T) {
+	pricingYml := testPricing(t, testSqlPricing)
+	tests := []struct {
+		name       string
+		database   string
+		edition    string
+		ha         bool
+		commitment int
+		want       map[string]float32 // Cost by resource of the line items
+	}{
+		{"zonal", "mysql", "", false, 0, map[string]float32{"sql": 2*30 + 8*5}},
+		{"high availability", "postgres", "", true, 0, map[string]float32{"sql": (2*30 + 8*5) * SqlHighAvailability}},
+		{"commitment", "mysql", "", false, 3, map[string]float32{"sql": 2*15 + 8*2}},
+		{"SQL Server license not covered by commitment", "sqlserver", "", false, 1, map[string]float32{"sql": 2*22 + 8*4, "sql-license": 2 * 90}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			if _, err := e.CalcSqlInstance("db", tt.database, tt.edition, "db-custom-2-8192", tt.ha, "us-central1", 1, tt.commitment); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(e.LineItems) != len(tt.want) {
+				t.Fatalf("line items = %d, want %d", len(e.LineItems), len(tt.want))
+			}
+			for _, lineItem := range e.LineItems {
+				if !testEqual(lineItem.Cost, tt.want[lineItem.Resource]) {
+					t.Errorf("cost of '%s' = %f, want %f", lineItem.Resource, lineItem.Cost, tt.want[lineItem.Resource])
+				}
+			}
+		})
+	}
+}
+
+func TestCalcSqlStorage(t *testing.T) {
+	pricingYml := testPricing(t, testSqlPricing)
+	tests := []struct {
+		name string
+		ha   bool
+		want float32
+	}{
+		{"zonal", false, 100*0.2 + 50*0.1},
+		{"high availability doubles storage but not backups", true, 100*0.2*SqlHighAvailability + 50*0.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			price, err := e.CalcSqlStorage("db", "", 100, 50, tt.ha, "us-central1", 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(price, tt.want) {
+				t.Errorf("price = %f, want %f", price, tt.want)
+			}
+		})
+	}
+}
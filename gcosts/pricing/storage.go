@@ -15,63 +15,129 @@ limitations under the License.
 */
 package pricing
 
-import (
-	"os"
-
-	"github.com/pterm/pterm"
-)
-
 // Storage bucket
 
-func CheckStorageBucket(pricingYml StructPricing, inputStorageClass string) Bucket {
+func CheckStorageBucket(pricingYml StructPricing, inputStorageClass string) (Bucket, error) {
 	resources := pricingYml.Storage.Bucket
 	resource, ok := resources[inputStorageClass]
-	if ok {
-		pterm.Success.Printf("Google Cloud Storage class '%s' found.\n", inputStorageClass)
-	} else {
-		pterm.Error.Printf("Google Cloud Storage class '%s' not found!\n", inputStorageClass)
-		os.Exit(1)
+	if !ok {
+		return Bucket{}, &ResourceError{Resource: "Google Cloud Storage class", Name: inputStorageClass}
 	}
-	return resource
+	return resource, nil
 }
 
-func CheckStorageRetrieval(pricingYml StructPricing, inputStorageClass string) Retrieval {
+func CheckStorageRetrieval(pricingYml StructPricing, inputStorageClass string) (Retrieval, error) {
 	resources := pricingYml.Storage.Retrieval
 	resource, ok := resources[inputStorageClass]
-	if ok {
-		pterm.Success.Printf("Google Cloud Storage class with retrieval fee '%s' found.\n", inputStorageClass)
-	} else {
-		pterm.Error.Printf("Google Cloud Storage class with retrieval fee '%s' not found!\n", inputStorageClass)
-		os.Exit(1)
+	if !ok {
+		return Retrieval{}, &ResourceError{Resource: "Google Cloud Storage class with retrieval fee", Name: inputStorageClass}
+	}
+	return resource, nil
+}
+
+func CheckStorageOperations(pricingYml StructPricing, inputStorageClass string) (Operations, error) {
+	resources := pricingYml.Storage.Operations
+	resource, ok := resources[inputStorageClass]
+	if !ok {
+		return Operations{}, &ResourceError{Resource: "Google Cloud Storage class with operations", Name: inputStorageClass}
+	}
+	return resource, nil
+}
+
+func CheckStorageEarlyDeletion(pricingYml StructPricing, inputStorageClass string) (EarlyDeletion, error) {
+	resources := pricingYml.Storage.EarlyDeletion
+	resource, ok := resources[inputStorageClass]
+	if !ok {
+		return EarlyDeletion{}, &ResourceError{Resource: "Google Cloud Storage class with minimum storage duration", Name: inputStorageClass}
+	}
+	return resource, nil
+}
+
+func CheckStorageReplication(pricingYml StructPricing, inputStorageClass string) (Replication, error) {
+	resources := pricingYml.Storage.Replication
+	resource, ok := resources[inputStorageClass]
+	if !ok {
+		return Replication{}, &ResourceError{Resource: "Google Cloud Storage dual-region or multi-region class", Name: inputStorageClass}
 	}
-	return resource
+	return resource, nil
 }
 
-func CostStorageBucket(pricingYml StructPricing, inputStorageClass string, inputRegion string) Cost {
-	resource := CheckStorageBucket(pricingYml, inputStorageClass)
+func CostStorageBucket(pricingYml StructPricing, inputStorageClass string, inputRegion string) (Cost, error) {
+	resource, err := CheckStorageBucket(pricingYml, inputStorageClass)
+	if err != nil {
+		return Cost{}, err
+	}
 	cost, ok := resource.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCS class '%s' in region '%s' found.\n", inputStorageClass, inputRegion)
-	} else {
-		pterm.Error.Printf("GCS class '%s' in region '%s' not found!\n", inputStorageClass, inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCS class", Name: inputStorageClass, Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func CostStorageRetrieval(pricingYml StructPricing, inputStorageClass string, inputRegion string) Cost {
-	resource := CheckStorageRetrieval(pricingYml, inputStorageClass)
+func CostStorageRetrieval(pricingYml StructPricing, inputStorageClass string, inputRegion string) (Cost, error) {
+	resource, err := CheckStorageRetrieval(pricingYml, inputStorageClass)
+	if err != nil {
+		return Cost{}, err
+	}
 	cost, ok := resource.Cost[inputRegion]
-	if ok {
-		pterm.Success.Printf("GCS class with retrieval fee '%s' in region '%s' found.\n", inputStorageClass, inputRegion)
-	} else {
-		pterm.Error.Printf("GCS class with retrieval fee '%s' in region '%s' not found!\n", inputStorageClass, inputRegion)
-		os.Exit(1)
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCS class with retrieval fee", Name: inputStorageClass, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+// StorageOperations is the number of operations the prices of Class A and Class B operations refer to
+const StorageOperations float32 = 1000
+
+func CostStorageOperationsClassA(pricingYml StructPricing, inputStorageClass string, inputRegion string) (Cost, error) {
+	resource, err := CheckStorageOperations(pricingYml, inputStorageClass)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := resource.ClassA[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCS class with Class A operations", Name: inputStorageClass, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostStorageOperationsClassB(pricingYml StructPricing, inputStorageClass string, inputRegion string) (Cost, error) {
+	resource, err := CheckStorageOperations(pricingYml, inputStorageClass)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := resource.ClassB[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCS class with Class B operations", Name: inputStorageClass, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostStorageEarlyDeletion(pricingYml StructPricing, inputStorageClass string, inputRegion string) (Cost, error) {
+	resource, err := CheckStorageEarlyDeletion(pricingYml, inputStorageClass)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := resource.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCS class with early deletion fee", Name: inputStorageClass, Region: inputRegion}
+	}
+	return cost, nil
+}
+
+func CostStorageReplication(pricingYml StructPricing, inputStorageClass string, inputRegion string) (Cost, error) {
+	resource, err := CheckStorageReplication(pricingYml, inputStorageClass)
+	if err != nil {
+		return Cost{}, err
+	}
+	cost, ok := resource.Cost[inputRegion]
+	if !ok {
+		return Cost{}, &ResourceError{Resource: "GCS class with replication fee", Name: inputStorageClass, Region: inputRegion}
 	}
-	return cost
+	return cost, nil
 }
 
-func returnStorageBucketName(defaultName string, inputName string) string {
+func (e *Estimate) returnStorageBucketName(defaultName string, inputName string) string {
 	var name string
 	if len(defaultName) > 0 {
 		name = defaultName
@@ -81,19 +147,24 @@ func returnStorageBucketName(defaultName string, inputName string) string {
 	if len(inputName) > 0 {
 		name = inputName
 	}
-	pterm.Info.Printf("GCS bucket name: '%s'\n", name)
+	e.info("GCS bucket name: '%s'", name)
 	return name
 }
 
-func CalcStorageBucket(pricingYml StructPricing, inputName string, inputStorageClass string, inputStorageData float32, inputStorageRetrieval float32, inputRegion string, inputDiscount float32) float32 {
-	name := returnStorageBucketName("", inputName)
+func (e *Estimate) CalcStorageBucket(inputName string, inputStorageClass string, inputStorageData float32, inputStorageRetrieval float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnStorageBucketName("", inputName)
 	discount, discountText := returnDiscount(inputDiscount)
-	price := (Month(CostStorageBucket(pricingYml, inputStorageClass, inputRegion)) * inputStorageData) * discount
-	pterm.Info.Printf("Price '%s' '%.2f' GiB per month: $%.2f %s\n", name, inputStorageData, price, discountText)
+	month, err := costMonth(CostStorageBucket(e.Pricing, inputStorageClass, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCS class '%s' in region '%s' found.", inputStorageClass, inputRegion)
+	price := (month * inputStorageData) * discount
+	e.info("Price '%s' '%.2f' GiB per month: $%.2f %s", name, inputStorageData, price, discountText)
 	if price > 0 {
-		LineItems = append(LineItems, LineItem{
-			File:     File,
-			Project:  Project,
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
 			Name:     name,
 			Type:     inputStorageClass, // Store Class in Type
 			Data:     inputStorageData,
@@ -104,12 +175,17 @@ func CalcStorageBucket(pricingYml StructPricing, inputName string, inputStorageC
 		})
 	}
 	if inputStorageRetrieval > 0 {
-		retrieval_fee := (Month(CostStorageRetrieval(pricingYml, inputStorageClass, inputRegion)) * inputStorageRetrieval) * discount
-		pterm.Info.Printf("Retrieval fee '%s' '%.2f' GiB per month: $%.2f %s\n", name, inputStorageData, price, discountText)
+		month, err := costMonth(CostStorageRetrieval(e.Pricing, inputStorageClass, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("GCS class with retrieval fee '%s' in region '%s' found.", inputStorageClass, inputRegion)
+		retrieval_fee := (month * inputStorageRetrieval) * discount
+		e.info("Retrieval fee '%s' '%.2f' GiB per month: $%.2f %s", name, inputStorageData, price, discountText)
 		if retrieval_fee > 0 {
-			LineItems = append(LineItems, LineItem{
-				File:     File,
-				Project:  Project,
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
 				Name:     name,
 				Type:     inputStorageClass, // Store Class in Type
 				Data:     inputStorageRetrieval,
@@ -120,5 +196,123 @@ func CalcStorageBucket(pricingYml StructPricing, inputName string, inputStorageC
 			})
 		}
 	}
-	return price
+	return price, nil
+}
+
+// CalcStorageBucketOperations adds one line item for the Class A and one for the Class B operations per month
+func (e *Estimate) CalcStorageBucketOperations(inputName string, inputStorageClass string, inputClassA float32, inputClassB float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnStorageBucketName("", inputName)
+	discount, discountText := returnDiscount(inputDiscount)
+	var price float32
+	if inputClassA > 0 {
+		month, err := costMonth(CostStorageOperationsClassA(e.Pricing, inputStorageClass, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("GCS class with Class A operations '%s' in region '%s' found.", inputStorageClass, inputRegion)
+		classA := (month * inputClassA / StorageOperations) * discount
+		e.info("Class A operations '%s' '%.0f' per month: $%.2f %s", name, inputClassA, classA, discountText)
+		if classA > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
+				Name:     name,
+				Type:     inputStorageClass, // Store Class in Type
+				Data:     inputClassA,
+				Region:   inputRegion,
+				Resource: "class-a-operations",
+				Discount: discount,
+				Cost:     classA,
+			})
+		}
+		price += classA
+	}
+	if inputClassB > 0 {
+		month, err := costMonth(CostStorageOperationsClassB(e.Pricing, inputStorageClass, inputRegion))
+		if err != nil {
+			return 0, err
+		}
+		e.success("GCS class with Class B operations '%s' in region '%s' found.", inputStorageClass, inputRegion)
+		classB := (month * inputClassB / StorageOperations) * discount
+		e.info("Class B operations '%s' '%.0f' per month: $%.2f %s", name, inputClassB, classB, discountText)
+		if classB > 0 {
+			e.LineItems = append(e.LineItems, LineItem{
+				File:     e.File,
+				Project:  e.Project,
+				Name:     name,
+				Type:     inputStorageClass, // Store Class in Type
+				Data:     inputClassB,
+				Region:   inputRegion,
+				Resource: "class-b-operations",
+				Discount: discount,
+				Cost:     classB,
+			})
+		}
+		price += classB
+	}
+	return price, nil
+}
+
+// CalcStorageBucketEarlyDeletion charges the remaining minimum storage duration of data deleted after inputStoredDays
+func (e *Estimate) CalcStorageBucketEarlyDeletion(inputName string, inputStorageClass string, inputStorageData float32, inputStoredDays float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnStorageBucketName("", inputName)
+	discount, discountText := returnDiscount(inputDiscount)
+	resource, err := CheckStorageEarlyDeletion(e.Pricing, inputStorageClass)
+	if err != nil {
+		return 0, err
+	}
+	month, err := costMonth(CostStorageEarlyDeletion(e.Pricing, inputStorageClass, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCS class with early deletion fee '%s' in region '%s' found.", inputStorageClass, inputRegion)
+	days := resource.Days - inputStoredDays
+	if !(days > 0) {
+		e.info("Data of '%s' stored longer than the minimum storage duration of %.0f days", name, resource.Days)
+		return 0, nil
+	}
+	months := days * 24 / HoursMonth
+	price := (month * inputStorageData * months) * discount
+	e.info("Early deletion fee '%s' '%.2f' GiB for the remaining %.0f days: $%.2f %s", name, inputStorageData, days, price, discountText)
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Name:     name,
+			Type:     inputStorageClass, // Store Class in Type
+			Data:     inputStorageData,
+			Region:   inputRegion,
+			Resource: "early-deletion",
+			Discount: discount,
+			Cost:     price,
+		})
+	}
+	return price, nil
+}
+
+// CalcStorageBucketReplication adds the replication of data written to a dual-region or multi-region bucket
+func (e *Estimate) CalcStorageBucketReplication(inputName string, inputStorageClass string, inputStorageData float32, inputRegion string, inputDiscount float32) (float32, error) {
+	name := e.returnStorageBucketName("", inputName)
+	discount, discountText := returnDiscount(inputDiscount)
+	month, err := costMonth(CostStorageReplication(e.Pricing, inputStorageClass, inputRegion))
+	if err != nil {
+		return 0, err
+	}
+	e.success("GCS class with replication fee '%s' in region '%s' found.", inputStorageClass, inputRegion)
+	price := (month * inputStorageData) * discount
+	e.info("Replication '%s' '%.2f' GiB per month: $%.2f %s", name, inputStorageData, price, discountText)
+	if price > 0 {
+		e.LineItems = append(e.LineItems, LineItem{
+			File:     e.File,
+			Project:  e.Project,
+			Name:     name,
+			Type:     inputStorageClass, // Store Class in Type
+			Data:     inputStorageData,
+			Region:   inputRegion,
+			Resource: "replication",
+			Discount: discount,
+			Cost:     price,
+		})
+	}
+	return price, nil
 }
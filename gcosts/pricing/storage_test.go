@@ -0,0 +1,229 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"testing"
+)
+
+const testStoragePricing = `
+storage:
+  bucket:
+    standard:
+      cost:
+        us-central1: {month: 0.02}
+    nearline:
+      cost:
+        us-central1: {month: 0.01}
+    coldline-dual:
+      cost:
+        nam4: {month: 0.008}
+  retrieval:
+    nearline:
+      cost:
+        us-central1: {month: 0.01}
+  operations:
+    standard:
+      class-a:
+        us-central1: {month: 0.005}
+      class-b:
+        us-central1: {month: 0.0004}
+    nearline:
+      class-a:
+        us-central1: {month: 0.01}
+  early-deletion:
+    nearline:
+      days: 30
+      cost:
+        us-central1: {month: 0.01}
+    coldline-dual:
+      days: 90
+      cost:
+        nam4: {month: 0.008}
+  replication:
+    coldline-dual:
+      cost:
+        nam4: {month: 0.02}
+`
+
+func TestCalcStorageBucketOperations(t *testing.T) {
+	pricingYml := testPricing(t, testStoragePricing)
+	tests := []struct {
+		name      string
+		class     string
+		classA    float32
+		classB    float32
+		discount  float32
+		want      float32
+		wantItems int
+		wantErr   bool
+	}{
+		{name: "Class A per 1000 operations", class: "standard", classA: 1000000, want: 5, wantItems: 1},
+		{name: "Class B per 1000 operations", class: "standard", classB: 1000000, want: 0.4, wantItems: 1},
+		{name: "Class A and Class B", class: "standard", classA: 2000, classB: 5000, want: 2*0.005 + 5*0.0004, wantItems: 2},
+		{name: "discount", class: "standard", classA: 1000000, classB: 1000000, discount: 0.5, want: (5 + 0.4) * 0.5, wantItems: 2},
+		{name: "no operations", class: "standard", want: 0},
+		{name: "no price of Class B operations", class: "nearline", classA: 1000, classB: 1000, wantErr: true},
+		{name: "unknown class", class: "archiv", classA: 1000, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcStorageBucketOperations("bucket", tt.class, tt.classA, tt.classB, "us-central1", tt.discount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+			if len(e.LineItems) != tt.wantItems {
+				t.Errorf("line items = %d, want %d", len(e.LineItems), tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestCalcStorageBucketEarlyDeletion(t *testing.T) {
+	pricingYml := testPricing(t, testStoragePricing)
+	tests := []struct {
+		name     string
+		class    string
+		region   string
+		data     float32
+		days     float32 // Stored before the deletion
+		discount float32
+		want     float32
+		wantErr  bool
+	}{
+		{name: "deleted immediately", class: "nearline", region: "us-central1", data: 100, want: 100 * 0.01 * 30 * 24 / HoursMonth},
+		{name: "remaining days", class: "nearline", region: "us-central1", data: 100, days: 20, want: 100 * 0.01 * 10 * 24 / HoursMonth},
+		{name: "discount", class: "nearline", region: "us-central1", data: 100, days: 20, discount: 0.5, want: 100 * 0.01 * 10 * 24 / HoursMonth * 0.5},
+		{name: "minimum storage duration", class: "nearline", region: "us-central1", data: 100, days: 30, want: 0},
+		{name: "stored longer", class: "nearline", region: "us-central1", data: 100, days: 45, want: 0},
+		{name: "dual-region", class: "coldline-dual", region: "nam4", data: 100, days: 0, want: 100 * 0.008 * 90 * 24 / HoursMonth},
+		{name: "no minimum storage duration", class: "standard", region: "us-central1", data: 100, wantErr: true},
+		{name: "no price in region", class: "nearline", region: "europe-west4", data: 100, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcStorageBucketEarlyDeletion("bucket", tt.class, tt.data, tt.days, tt.region, tt.discount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+			if tt.want > 0 && (len(e.LineItems) != 1 || e.LineItems[0].Resource != "early-deletion") {
+				t.Errorf("line items = %+v, want one early deletion line item", e.LineItems)
+			}
+		})
+	}
+}
+
+func TestCalcStorageBucketReplication(t *testing.T) {
+	pricingYml := testPricing(t, testStoragePricing)
+	tests := []struct {
+		name     string
+		class    string
+		region   string
+		data     float32
+		discount float32
+		want     float32
+		wantErr  bool
+	}{
+		{name: "dual-region", class: "coldline-dual", region: "nam4", data: 100, want: 2},
+		{name: "discount", class: "coldline-dual", region: "nam4", data: 100, discount: 0.5, want: 1},
+		{name: "no replication", class: "coldline-dual", region: "nam4", want: 0},
+		{name: "regional class", class: "standard", region: "us-central1", data: 100, wantErr: true},
+		{name: "no price in region", class: "coldline-dual", region: "eur4", data: 100, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcStorageBucketReplication("bucket", tt.class, tt.data, tt.region, tt.discount)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalcStorageBucket(t *testing.T) {
+	pricingYml := testPricing(t, testStoragePricing)
+	tests := []struct {
+		name          string
+		class         string
+		data          float32
+		retrieval     float32
+		want          float32 // Storage
+		wantRetrieval float32
+		wantErr       bool
+	}{
+		{name: "storage", class: "standard", data: 100, want: 2},
+		{name: "storage and retrieval", class: "nearline", data: 100, retrieval: 50, want: 1, wantRetrieval: 0.5},
+		{name: "no retrieval fee", class: "standard", data: 100, retrieval: 50, wantErr: true},
+		{name: "unknown class", class: "archiv", data: 100, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			got, err := e.CalcStorageBucket("bucket", tt.class, tt.data, tt.retrieval, "us-central1", 0)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("price = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("price = %f, want %f", got, tt.want)
+			}
+			var retrieval float32
+			for _, lineItem := range e.LineItems {
+				if lineItem.Resource == "retrieval" {
+					retrieval += lineItem.Cost
+				}
+			}
+			if !testEqual(retrieval, tt.wantRetrieval) {
+				t.Errorf("retrieval = %f, want %f", retrieval, tt.wantRetrieval)
+			}
+		})
+	}
+}
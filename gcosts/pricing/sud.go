@@ -0,0 +1,63 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"strings"
+)
+
+// Sustained use discounts (SUD) of the machine families.
+// Rate of the base price for each incremental usage bracket (quarter of the month).
+// build/pricing.pl is authoritative for the brackets and the machine families (the monthly prices include the SUD),
+// TestSustainedUseDiscountPricingPl checks that both agree.
+// https://cloud.google.com/compute/docs/sustained-use-discounts
+var (
+	sustainedUseDiscountN1 = []float32{1, 0.8, 0.6, 0.4}      // 0%-25%, 25%-50%, 50%-75%, 75%-100%
+	sustainedUseDiscountN2 = []float32{1, 0.8678, 0.733, 0.6} // 0%-25%, 25%-50%, 50%-75%, 75%-100%
+	sustainedUseDiscounts  = map[string][]float32{
+		"n1":     sustainedUseDiscountN1,
+		"custom": sustainedUseDiscountN1, // N1 custom machine types
+		"f1":     sustainedUseDiscountN1,
+		"g1":     sustainedUseDiscountN1,
+		"m1":     sustainedUseDiscountN1,
+		"m2":     sustainedUseDiscountN1,
+		"n2":     sustainedUseDiscountN2,
+		"n2d":    sustainedUseDiscountN2,
+		"c2":     sustainedUseDiscountN2,
+	}
+)
+
+// SustainedUseDiscount returns the SUD of the machine type for the runtime in hours per month
+// as multiplier of the price per hour (1 = no discount).
+// Returns false if the machine family does not get sustained use discounts.
+func SustainedUseDiscount(inputMachineType string, inputHours float32) (float32, bool) {
+	family, _, _ := strings.Cut(inputMachineType, "-")
+	brackets, ok := sustainedUseDiscounts[family]
+	if !ok {
+		return 1, false
+	}
+	if !(inputHours > 0) {
+		return 1, true
+	}
+	hours := min(inputHours, HoursMonth)
+	bracket := HoursMonth / float32(len(brackets))
+	var discounted float32
+	for i, rate := range brackets {
+		used := min(max(hours-float32(i)*bracket, 0), bracket)
+		discounted = discounted + used*rate
+	}
+	return discounted / hours, true
+}
@@ -0,0 +1,202 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSustainedUseDiscount(t *testing.T) {
+	quarter := HoursMonth / 4
+	tests := []struct {
+		name        string
+		machineType string
+		hours       float32
+		want        float32
+		wantOk      bool
+	}{
+		{"N1 first bracket", "n1-standard-4", quarter, 1, true},
+		{"N1 second bracket", "n1-standard-4", 2 * quarter, (1 + 0.8) / 2, true},
+		{"N1 partial third bracket", "n1-standard-4", 2.5 * quarter, (1 + 0.8 + 0.5*0.6) / 2.5, true},
+		{"N1 full month", "n1-standard-4", HoursMonth, 0.7, true},
+		{"N1 more than one month", "n1-standard-4", 2 * HoursMonth, 0.7, true},
+		{"N1 without runtime", "n1-standard-4", 0, 1, true},
+		{"N1 custom machine type", "custom-2-15360", HoursMonth, 0.7, true},
+		{"F1 shared core", "f1-micro", HoursMonth, 0.7, true},
+		{"G1 shared core", "g1-small", HoursMonth, 0.7, true},
+		{"M1 memory-optimized", "m1-megamem-96", HoursMonth, 0.7, true},
+		{"M2 memory-optimized", "m2-ultramem-208", HoursMonth, 0.7, true},
+		{"N2 second bracket", "n2-standard-8", 2 * quarter, (1 + 0.8678) / 2, true},
+		{"N2 full month", "n2-standard-8", HoursMonth, (1 + 0.8678 + 0.733 + 0.6) / 4, true},
+		{"N2D full month", "n2d-standard-8", HoursMonth, (1 + 0.8678 + 0.733 + 0.6) / 4, true},
+		{"C2 full month", "c2-standard-8", HoursMonth, (1 + 0.8678 + 0.733 + 0.6) / 4, true},
+		{"E2 not eligible", "e2-standard-4", HoursMonth, 1, false},
+		{"N4 not eligible", "n4-standard-4", HoursMonth, 1, false},
+		{"M3 not eligible", "m3-megamem-64", HoursMonth, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SustainedUseDiscount(tt.machineType, tt.hours)
+			if ok != tt.wantOk {
+				t.Fatalf("eligible = %t, want %t", ok, tt.wantOk)
+			}
+			if !testEqual(got, tt.want) {
+				t.Errorf("SustainedUseDiscount(%s, %.1f) = %f, want %f", tt.machineType, tt.hours, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalcComputeInstanceSud(t *testing.T) {
+	pricingYml := testPricing(t, `
+compute:
+  instance:
+    n1-standard-1:
+      cost:
+        us-central1: {hour: 0.1, hour_spot: 0.02, month: 51.1, month_spot: 14.6}
+    e2-standard-2:
+      cost:
+        us-central1: {hour: 0.1, month: 73}
+`)
+	tests := []struct {
+		name        string
+		machineType string
+		spot        bool
+		hours       float32
+		wantCost    float32
+		wantSud     float32
+	}{
+		{"partial month with SUD", "n1-standard-1", false, 365, 0.1 * 365 * 0.9, 0.9},
+		{"full month includes SUD", "n1-standard-1", false, 0, 51.1, 0.7},
+		{"partial month without SUD", "e2-standard-2", false, 365, 0.1 * 365, 0},
+		{"Spot VM without SUD", "n1-standard-1", true, 365, 0.02 * 365, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			price, err := e.CalcComputeInstance("vm", tt.machineType, "us-central1", 1, 0, tt.spot, false, tt.hours)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !testEqual(price, tt.wantCost) {
+				t.Errorf("price = %f, want %f", price, tt.wantCost)
+			}
+			if len(e.LineItems) != 1 {
+				t.Fatalf("line items = %d, want 1", len(e.LineItems))
+			}
+			if !testEqual(e.LineItems[0].Sud, tt.wantSud) {
+				t.Errorf("SUD = %f, want %f", e.LineItems[0].Sud, tt.wantSud)
+			}
+		})
+	}
+}
+
+// TestSustainedUseDiscountPricingPl checks the brackets and machine families against build/pricing.pl
+func TestSustainedUseDiscountPricingPl(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "..", "build", "pricing.pl"))
+	if err != nil {
+		t.Skipf("pricing.pl not found: %v", err)
+	}
+	pl := string(content)
+
+	// Brackets, e.g. my %sustained_use_discount_n1 = ( 1 => 1, 2 => 0.8, ... );
+	tables := map[string][]float32{}
+	for _, match := range regexp.MustCompile(`my %(\w*sustained_use_discount\w*) = \(([^)]*)\);`).FindAllStringSubmatch(pl, -1) {
+		var brackets []float32
+		for _, bracket := range regexp.MustCompile(`(?m)^\s*\d+\s*=>\s*([0-9.]+)`).FindAllStringSubmatch(match[2], -1) {
+			rate, err := strconv.ParseFloat(bracket[1], 32)
+			if err != nil {
+				t.Fatalf("bracket of '%s': %v", match[1], err)
+			}
+			brackets = append(brackets, float32(rate))
+		}
+		tables[match[1]] = brackets
+	}
+	for name, want := range map[string][]float32{
+		"sustained_use_discount_n1":  sustainedUseDiscountN1,
+		"sustained_use_discount_n2":  sustainedUseDiscountN2,
+		"gpu_sustained_use_discount": sustainedUseDiscountN1,
+		"sustained_use_discount":     {1, 1, 1, 1}, // No SUD
+	} {
+		brackets, ok := tables[name]
+		if !ok {
+			t.Errorf("table '%s' not found in pricing.pl", name)
+		} else if !slices.Equal(brackets, want) {
+			t.Errorf("brackets of '%s' in pricing.pl = %v, want %v", name, brackets, want)
+		}
+	}
+
+	// Machine families with SUD, e.g. elsif ($type eq 'n1') { ... %sustained_use_discount = %sustained_use_discount_n1 if $add_sud;
+	// in the mapping of the machine types
+	start := strings.Index(pl, "my %sustained_use_discount = (")
+	end := strings.Index(pl, "# Calculate sustained use discount")
+	if start < 0 || end < start {
+		t.Fatal("mapping of the machine types not found in pricing.pl")
+	}
+	families := map[string]string{}
+	var family string
+	for _, line := range strings.Split(pl[start:end], "\n") {
+		if match := regexp.MustCompile(`if \(\$type eq '([a-z0-9-]+)'\)`).FindStringSubmatch(line); match != nil {
+			family = match[1]
+			if _, ok := families[family]; !ok {
+				families[family] = ""
+			}
+		} else if match := regexp.MustCompile(`%sustained_use_discount = %(sustained_use_discount_\w+)`).FindStringSubmatch(line); match != nil && len(family) > 0 {
+			families[family] = match[1]
+		}
+	}
+	for family, table := range families {
+		// N1 custom machine types are named custom-CPU-RAM, the others e.g. n2-custom-CPU-RAM
+		goFamily := strings.TrimSuffix(family, "-custom")
+		if family == "n1-custom" {
+			goFamily = "custom"
+		}
+		brackets, ok := sustainedUseDiscounts[goFamily]
+		switch {
+		case len(table) == 0 && ok:
+			t.Errorf("machine family '%s' has no SUD in pricing.pl, but %v", family, brackets)
+		case len(table) > 0 && !slices.Equal(brackets, tables[table]):
+			t.Errorf("SUD of machine family '%s' = %v, want %s %v of pricing.pl", family, brackets, table, tables[table])
+		}
+	}
+	for goFamily := range sustainedUseDiscounts {
+		family := goFamily
+		if goFamily == "custom" {
+			family = "n1-custom"
+		}
+		if _, ok := families[family]; !ok {
+			t.Errorf("machine family '%s' with SUD not found in pricing.pl", goFamily)
+		}
+	}
+
+	// Average SUD of custom machine types for the full month, e.g. 'n1' => (1+0.8+0.6+0.4)/4,
+	for _, match := range regexp.MustCompile(`'(\w+)'\s*=>\s*\(([0-9.+]+)\)/4`).FindAllStringSubmatch(pl, -1) {
+		var brackets []float32
+		for _, rate := range strings.Split(match[2], "+") {
+			value, _ := strconv.ParseFloat(rate, 32)
+			brackets = append(brackets, float32(value))
+		}
+		if !slices.Equal(brackets, sustainedUseDiscounts[match[1]]) {
+			t.Errorf("average SUD of custom machine family '%s' in pricing.pl = %v, want %v", match[1], brackets, sustainedUseDiscounts[match[1]])
+		}
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Scopes for the aggregation of tiered usage
+const (
+	TierScopeTotal          = "total"           // All usage files
+	TierScopeProject        = "project"         // Per project
+	TierScopeBillingAccount = "billing-account" // Per billing account of the usage files
+)
+
+// Tier of a tiered price.
+// The price per unit applies from the start amount to the start amount of the next tier.
+type Tier struct {
+	Start float32 // Start usage amount, e.g. GiB for traffic or MiB for monitoring data
+	Month float32 // Price per unit
+}
+
+// TieredPrice returns the price of the amount with any number of tiers
+func TieredPrice(tiers []Tier, amount float32) float32 {
+	var price float32
+	for i, tier := range tiers {
+		if amount <= tier.Start {
+			break
+		}
+		end := amount
+		if i+1 < len(tiers) && tiers[i+1].Start < amount {
+			end = tiers[i+1].Start
+		}
+		price = price + (end-tier.Start)*tier.Month
+	}
+	return price
+}
+
+// returnTiers returns the tiers of the region sorted by start amount.
+// Pricing information without tiers (older pricing files) falls back to the fixed ranges.
+func returnTiers(tiers map[string][]Tier, inputRegion string, fallback func() ([]Tier, error)) ([]Tier, error) {
+	regionTiers, ok := tiers[inputRegion]
+	if !ok || len(regionTiers) == 0 {
+		return fallback()
+	}
+	return slices.SortedFunc(slices.Values(regionTiers), func(a, b Tier) int {
+		switch {
+		case a.Start < b.Start:
+			return -1
+		case a.Start > b.Start:
+			return 1
+		}
+		return 0
+	}), nil
+}
+
+// returnFreeTiers returns the tiers with the free usage as first tier
+func returnFreeTiers(free float32, price float32) []Tier {
+	if free > 0 {
+		return []Tier{{Start: 0, Month: 0}, {Start: free, Month: price}}
+	}
+	return []Tier{{Start: 0, Month: price}}
+}
+
+// returnRangeTiers returns the tiers of fixed ranges with the monthly prices of the cost functions
+func returnRangeTiers(pricingYml StructPricing, inputRegion string, starts []float32, costs ...func(StructPricing, string) (Cost, error)) ([]Tier, error) {
+	tiers := make([]Tier, len(costs))
+	for i, cost := range costs {
+		month, err := costMonth(cost(pricingYml, inputRegion))
+		if err != nil {
+			return nil, err
+		}
+		tiers[i] = Tier{Start: starts[i], Month: month}
+	}
+	return tiers, nil
+}
+
+// CheckTierScope returns an error if the scope for the aggregation of tiered usage is unknown
+func CheckTierScope(scope string) error {
+	_, err := returnTierScope(scope)
+	return err
+}
+
+// returnTierScope returns a function with the description of the scope of a line item
+func returnTierScope(scope string) (func(lineItem LineItem) string, error) {
+	switch scope {
+	case TierScopeTotal:
+		return func(lineItem LineItem) string { return "all usage files" }, nil
+	case TierScopeProject:
+		return func(lineItem LineItem) string { return fmt.Sprintf("project '%s'", lineItem.Project) }, nil
+	case TierScopeBillingAccount:
+		return func(lineItem LineItem) string {
+			if len(lineItem.billingAccount) == 0 {
+				return "default billing account"
+			}
+			return fmt.Sprintf("billing account '%s'", lineItem.billingAccount)
+		}, nil
+	}
+	return nil, fmt.Errorf("tier scope '%s' not supported, use %s, %s or %s", scope, TierScopeTotal, TierScopeProject, TierScopeBillingAccount)
+}
+
+// AggregateTiers prices the tiered usage (e.g. internet egress traffic) of all line items
+// with the same scope, resource, type and region together, because tiers are billed on the total usage.
+// Free usage (e.g. Cloud Run or BigQuery) is granted once per scope, so these line items are aggregated over all regions.
+// Every line item is priced with its own tiers at the total usage and gets the share of its usage.
+// Line items of tiered usage without costs (e.g. within the free usage) are removed afterwards.
+func (e *Estimate) AggregateTiers(scope string) error {
+	scopeName, err := returnTierScope(scope)
+	if err != nil {
+		return err
+	}
+
+	type group struct {
+		name      string
+		region    string
+		lineItems []int
+		data      float32
+	}
+	var groups []*group
+	index := map[string]*group{}
+	for i, lineItem := range e.LineItems {
+		if len(lineItem.tiers) == 0 {
+			continue
+		}
+		name := scopeName(lineItem)
+		region := lineItem.Region
+		if lineItem.free {
+			region = "all regions"
+		}
+		key := fmt.Sprintf("%s|%s|%s|%s", name, lineItem.Resource, lineItem.Type, region)
+		g, ok := index[key]
+		if !ok {
+			g = &group{name: name, region: region}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.lineItems = append(g.lineItems, i)
+		g.data = g.data + lineItem.Data
+	}
+
+	// Aggregation is not part of one usage file
+	file := e.File
+	e.File = ""
+	defer func() { e.File = file }()
+	for _, g := range groups {
+		if len(g.lineItems) < 2 {
+			continue
+		}
+		var price float32
+		for _, i := range g.lineItems {
+			lineItem := &e.LineItems[i]
+			share := float32(float64(TieredPrice(lineItem.tiers, g.data)) * float64(lineItem.Data) / float64(g.data))
+			lineItem.Cost = share * lineItem.Discount
+			price = price + share
+		}
+		e.info("Tiered usage '%s' of %d line items in '%s' aggregated for %s: %.2f, price $%.2f",
+			e.LineItems[g.lineItems[0]].Type, len(g.lineItems), g.region, g.name, g.data, price)
+	}
+	e.LineItems = slices.DeleteFunc(e.LineItems, func(lineItem LineItem) bool {
+		return len(lineItem.tiers) > 0 && !(lineItem.Cost > 0)
+	})
+	return nil
+}
@@ -0,0 +1,390 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+const testTierPricing = `
+region:
+  us-central1: {}
+  europe-west4: {}
+  asia-east1: {}
+compute:
+  network:
+    traffic:
+      egress:
+        internet:
+          tiers:
+            us-central1:
+              - {start: 10240, month: 0.08}
+              - {start: 0, month: 0.12}
+              - {start: 1024, month: 0.11}
+          cost:
+            0-1:
+              europe-west4: {month: 0.12}
+            1-10:
+              europe-west4: {month: 0.11}
+            10n:
+              europe-west4: {month: 0.08}
+          china:
+            tiers:
+              us-central1:
+                - {start: 0, month: 0.23}
+                - {start: 1024, month: 0.22}
+                - {start: 10240, month: 0.20}
+            cost:
+              0-1:
+                europe-west4: {month: 0.23}
+              1-10:
+                europe-west4: {month: 0.22}
+              10n:
+                europe-west4: {month: 0.20}
+          australia:
+            tiers:
+              us-central1:
+                - {start: 0, month: 0.19}
+                - {start: 1024, month: 0.18}
+                - {start: 10240, month: 0.15}
+            cost:
+              0-1:
+                europe-west4: {month: 0.19}
+              1-10:
+                europe-west4: {month: 0.18}
+              10n:
+                europe-west4: {month: 0.15}
+`
+
+func TestTieredPrice(t *testing.T) {
+	tiers := []Tier{{Start: 0, Month: 0.12}, {Start: 1024, Month: 0.11}, {Start: 10240, Month: 0.08}}
+	tests := []struct {
+		name   string
+		tiers  []Tier
+		amount float32
+		want   float32
+	}{
+		{"no usage", tiers, 0, 0},
+		{"partial first tier", tiers, 100, 100 * 0.12},
+		{"end of first tier", tiers, 1024, 1024 * 0.12},
+		{"start of second tier", tiers, 1025, 1024*0.12 + 1*0.11},
+		{"partial second tier", tiers, 5000, 1024*0.12 + 3976*0.11},
+		{"end of second tier", tiers, 10240, 1024*0.12 + 9216*0.11},
+		{"last tier", tiers, 20000, 1024*0.12 + 9216*0.11 + 9760*0.08},
+		{"single tier", []Tier{{Start: 0, Month: 0.5}}, 10, 5},
+		{"free tier", returnFreeTiers(50, 0.5), 60, 5},
+		{"within free tier", returnFreeTiers(50, 0.5), 50, 0},
+		{"no tiers", nil, 100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TieredPrice(tt.tiers, tt.amount); !testEqual(got, tt.want) {
+				t.Errorf("TieredPrice(%v, %.0f) = %f, want %f", tt.tiers, tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReturnTiers(t *testing.T) {
+	pricingYml := testPricing(t, testTierPricing)
+	tests := []struct {
+		name    string
+		tiers   func(StructPricing, string) ([]Tier, error)
+		region  string
+		want    []Tier
+		wantErr bool
+	}{
+		{
+			name:   "sorted tiers of the region",
+			tiers:  CostComputeNetworkTrafficEgressTiers,
+			region: "us-central1",
+			want:   []Tier{{Start: 0, Month: 0.12}, {Start: 1024, Month: 0.11}, {Start: 10240, Month: 0.08}},
+		},
+		{
+			name:   "fallback to fixed ranges if region has no tiers",
+			tiers:  CostComputeNetworkTrafficEgressTiers,
+			region: "europe-west4",
+			want:   []Tier{{Start: 0, Month: 0.12}, {Start: 1024, Month: 0.11}, {Start: 10240, Month: 0.08}},
+		},
+		{
+			name:    "region without tiers and ranges",
+			tiers:   CostComputeNetworkTrafficEgressTiers,
+			region:  "asia-east1",
+			wantErr: true,
+		},
+		{
+			name:   "China destinations",
+			tiers:  CostComputeNetworkTrafficEgressChinaTiers,
+			region: "us-central1",
+			want:   []Tier{{Start: 0, Month: 0.23}, {Start: 1024, Month: 0.22}, {Start: 10240, Month: 0.20}},
+		},
+		{
+			name:   "China destinations fallback",
+			tiers:  CostComputeNetworkTrafficEgressChinaTiers,
+			region: "europe-west4",
+			want:   []Tier{{Start: 0, Month: 0.23}, {Start: 1024, Month: 0.22}, {Start: 10240, Month: 0.20}},
+		},
+		{
+			name:   "Australia destinations",
+			tiers:  CostComputeNetworkTrafficEgressAustraliaTiers,
+			region: "us-central1",
+			want:   []Tier{{Start: 0, Month: 0.19}, {Start: 1024, Month: 0.18}, {Start: 10240, Month: 0.15}},
+		},
+		{
+			name:   "Australia destinations fallback",
+			tiers:  CostComputeNetworkTrafficEgressAustraliaTiers,
+			region: "europe-west4",
+			want:   []Tier{{Start: 0, Month: 0.19}, {Start: 1024, Month: 0.18}, {Start: 10240, Month: 0.15}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tiers(pricingYml, tt.region)
+			if tt.wantErr {
+				var resourceError *ResourceError
+				if !errors.As(err, &resourceError) {
+					t.Fatalf("error = %v, want ResourceError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("tiers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReturnRangeTiers(t *testing.T) {
+	pricingYml := testPricing(t, testTierPricing)
+	tests := []struct {
+		name    string
+		region  string
+		costs   []func(StructPricing, string) (Cost, error)
+		want    []Tier
+		wantErr bool
+	}{
+		{
+			name:   "all ranges",
+			region: "europe-west4",
+			costs:  []func(StructPricing, string) (Cost, error){CostComputeNetworkTrafficEgressTiB0_1, CostComputeNetworkTrafficEgressTiB1_10, CostComputeNetworkTrafficEgressTiB10n},
+			want:   []Tier{{Start: 0, Month: 0.12}, {Start: 1024, Month: 0.11}, {Start: 10240, Month: 0.08}},
+		},
+		{
+			name:   "China destinations",
+			region: "europe-west4",
+			costs:  []func(StructPricing, string) (Cost, error){CostComputeNetworkTrafficEgressChinaTiB0_1, CostComputeNetworkTrafficEgressChinaTiB1_10, CostComputeNetworkTrafficEgressChinaTiB10n},
+			want:   []Tier{{Start: 0, Month: 0.23}, {Start: 1024, Month: 0.22}, {Start: 10240, Month: 0.20}},
+		},
+		{
+			name:    "missing region",
+			region:  "us-central1",
+			costs:   []func(StructPricing, string) (Cost, error){CostComputeNetworkTrafficEgressTiB0_1, CostComputeNetworkTrafficEgressTiB1_10, CostComputeNetworkTrafficEgressTiB10n},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := returnRangeTiers(pricingYml, tt.region, trafficEgressStarts, tt.costs...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("tiers = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("tiers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalcComputeNetworkTrafficEgress(t *testing.T) {
+	e := NewEstimate(testPricing(t, testTierPricing))
+	price, err := e.CalcComputeNetworkTrafficEgress("egress", 2048, 100, 100, "us-central1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	world := float32(1024*0.12 + 1024*0.11)
+	want := map[string]float32{"traffic": world, "traffic-cn": 100 * 0.23, "traffic-au": 100 * 0.19}
+	if len(e.LineItems) != len(want) {
+		t.Fatalf("line items = %d, want %d", len(e.LineItems), len(want))
+	}
+	for _, lineItem := range e.LineItems {
+		if !testEqual(lineItem.Cost, want[lineItem.Type]) {
+			t.Errorf("cost of '%s' = %f, want %f", lineItem.Type, lineItem.Cost, want[lineItem.Type])
+		}
+	}
+	if total := world + 100*0.23 + 100*0.19; !testEqual(price, total) {
+		t.Errorf("price = %f, want %f", price, total)
+	}
+}
+
+func TestAggregateTiers(t *testing.T) {
+	pricingYml := testPricing(t, testTierPricing+`
+serverless:
+  request:
+    requests:
+      free: 2000000
+      cost:
+        us-central1: {month: 0.4}
+        europe-west4: {month: 0.5}
+`)
+	type usage struct {
+		region   string
+		world    float32 // Internet egress traffic in GiB
+		requests float32 // Cloud Run requests
+	}
+	tests := []struct {
+		name   string
+		usages []usage
+		want   []float32 // Cost of the line items
+	}{
+		{
+			name:   "traffic of one region is aggregated",
+			usages: []usage{{region: "us-central1", world: 600}, {region: "us-central1", world: 600}},
+			want:   []float32{(1024*0.12 + 176*0.11) / 2, (1024*0.12 + 176*0.11) / 2},
+		},
+		{
+			name:   "traffic of different regions is not aggregated",
+			usages: []usage{{region: "us-central1", world: 600}, {region: "europe-west4", world: 600}},
+			want:   []float32{600 * 0.12, 600 * 0.12},
+		},
+		{
+			name:   "free usage is granted once for two regions",
+			usages: []usage{{region: "us-central1", requests: 1500000}, {region: "europe-west4", requests: 1500000}},
+			want:   []float32{0.4 / 2, 0.5 / 2},
+		},
+		{
+			name:   "free usage covers both regions",
+			usages: []usage{{region: "us-central1", requests: 1000000}, {region: "europe-west4", requests: 1000000}},
+			want:   nil, // Line items without costs are removed
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			for _, u := range tt.usages {
+				if u.world > 0 {
+					if _, err := e.CalcComputeNetworkTrafficEgress("", u.world, 0, 0, u.region, 1); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				}
+				if u.requests > 0 {
+					if _, err := e.CalcServerless("", "", 0, 0, u.requests, 0, 0, 0, u.region, 1); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				}
+			}
+			if err := e.AggregateTiers(TierScopeTotal); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(e.LineItems) != len(tt.want) {
+				t.Fatalf("line items = %d, want %d", len(e.LineItems), len(tt.want))
+			}
+			for i, lineItem := range e.LineItems {
+				if !testEqual(lineItem.Cost, tt.want[i]) {
+					t.Errorf("cost of line item %d in '%s' = %f, want %f", i, lineItem.Region, lineItem.Cost, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAggregateTiersMonitoring(t *testing.T) {
+	pricingYml := testPricing(t, `
+region:
+  europe-west4: {}
+monitoring:
+  data:
+    tiers:
+      europe-west4:
+        - {start: 0, month: 0}
+        - {start: 150, month: 0.258}
+        - {start: 100000, month: 0.151}
+        - {start: 250000, month: 0.061}
+`)
+	tests := []struct {
+		name  string
+		scope string
+		data  []float32 // MiB per project
+		want  []float32 // Cost of the line items
+	}{
+		{
+			name:  "free usage of one project",
+			scope: TierScopeTotal,
+			data:  []float32{100},
+			want:  nil, // Line items without costs are removed
+		},
+		{
+			name:  "projects within the free usage exceed it in total",
+			scope: TierScopeTotal,
+			data:  []float32{100, 100, 100},
+			want:  []float32{150 * 0.258 / 3, 150 * 0.258 / 3, 150 * 0.258 / 3},
+		},
+		{
+			name:  "costs are shared by usage",
+			scope: TierScopeTotal,
+			data:  []float32{100, 100, 200},
+			want:  []float32{250 * 0.258 / 4, 250 * 0.258 / 4, 250 * 0.258 / 2},
+		},
+		{
+			name:  "free usage per project",
+			scope: TierScopeProject,
+			data:  []float32{100, 100, 200},
+			want:  []float32{50 * 0.258},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEstimate(pricingYml)
+			var total float32
+			for i, data := range tt.data {
+				e.Project = fmt.Sprintf("project-%d", i)
+				if _, err := e.CalcMonitoring("", data, "europe-west4", 1); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if err := e.AggregateTiers(tt.scope); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(e.LineItems) != len(tt.want) {
+				t.Fatalf("line items = %d, want %d", len(e.LineItems), len(tt.want))
+			}
+			for i, lineItem := range e.LineItems {
+				total += lineItem.Cost
+				if !testEqual(lineItem.Cost, tt.want[i]) {
+					t.Errorf("cost of line item %d of '%s' = %f, want %f", i, lineItem.Project, lineItem.Cost, tt.want[i])
+				}
+			}
+			var want float32
+			for _, cost := range tt.want {
+				want += cost
+			}
+			if !testEqual(total, want) {
+				t.Errorf("total = %f, want %f", total, want)
+			}
+		})
+	}
+}
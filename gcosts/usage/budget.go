@@ -0,0 +1,55 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Limit is the monthly budget and the maximum growth in percent
+type Limit struct {
+	Budget float32
+	Growth float32
+}
+
+// StructBudget is the global budget configuration for the total costs, usage files and projects
+type StructBudget struct {
+	Total    Limit
+	Files    map[string]Limit
+	Projects map[string]Limit
+}
+
+// BudgetYml reads the budget YAML file.
+// Unknown fields (e.g. typos) are errors.
+func BudgetYml(file string) (StructBudget, error) {
+	s := StructBudget{}
+	filecontent, err := os.ReadFile(file)
+	if err != nil {
+		return s, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(filecontent))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&s); err != nil && !errors.Is(err, io.EOF) {
+		return s, fmt.Errorf("budget YAML file '%s' could not be processed: %w", file, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBudgetYml(t *testing.T) {
+	tests := []struct {
+		name    string
+		yml     string
+		want    StructBudget
+		wantErr string
+	}{
+		{
+			name: "total, files and projects",
+			yml: `
+total:
+  budget: 1000
+  growth: 10
+files:
+  web.yml:
+    budget: 100
+projects:
+  my-project:
+    growth: 5
+`,
+			want: StructBudget{
+				Total:    Limit{Budget: 1000, Growth: 10},
+				Files:    map[string]Limit{"web.yml": {Budget: 100}},
+				Projects: map[string]Limit{"my-project": {Growth: 5}},
+			},
+		},
+		{
+			name: "empty",
+			yml:  "",
+		},
+		{
+			name:    "unknown field",
+			yml:     "total:\n  budgets: 1000\n",
+			wantErr: "line 2: field budgets not found",
+		},
+		{
+			name:    "unknown scope",
+			yml:     "project:\n  my-project:\n    budget: 100\n",
+			wantErr: "line 1: field project not found",
+		},
+		{
+			name:    "type error",
+			yml:     "total:\n  budget: unlimited\n",
+			wantErr: "line 2: cannot unmarshal !!str `unlimited`",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "budget.yml")
+			if err := os.WriteFile(path, []byte(test.yml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			budget, err := BudgetYml(path)
+			if len(test.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if budget.Total != test.want.Total || len(budget.Files) != len(test.want.Files) || len(budget.Projects) != len(test.want.Projects) {
+				t.Fatalf("budget = %+v, want %+v", budget, test.want)
+			}
+			for file, limit := range test.want.Files {
+				if budget.Files[file] != limit {
+					t.Errorf("file %s = %+v, want %+v", file, budget.Files[file], limit)
+				}
+			}
+			for project, limit := range test.want.Projects {
+				if budget.Projects[project] != limit {
+					t.Errorf("project %s = %+v, want %+v", project, budget.Projects[project], limit)
+				}
+			}
+		})
+	}
+}
+
+func TestBudgetYmlNotFound(t *testing.T) {
+	if _, err := BudgetYml(filepath.Join(t.TempDir(), "budget.yml")); err == nil {
+		t.Error("want error")
+	}
+}
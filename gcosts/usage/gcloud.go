@@ -0,0 +1,228 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+)
+
+// Kinds of gcloud compute resources and their asset types (gcloud asset list --content-type=resource)
+var gcloudAssetKinds = map[string]string{
+	"compute.googleapis.com/Instance":  "compute#instance",
+	"compute.googleapis.com/Disk":      "compute#disk",
+	"compute.googleapis.com/Address":   "compute#address",
+	"compute.googleapis.com/Router":    "compute#router",
+	"compute.googleapis.com/VpnTunnel": "compute#vpnTunnel",
+	"storage.googleapis.com/Bucket":    "storage#bucket",
+}
+
+var gcloudProject = regexp.MustCompile(`projects/([^/]+)`)
+
+type gcloudResource struct {
+	Kind    string
+	Name    string // Asset name or self link
+	Project string
+	Data    values
+}
+
+// Gcloud converts the resources of gcloud JSON exports to usage, one usage per project.
+// Supported exports are gcloud compute instances/disks/addresses/routers/vpn-tunnels list --format=json
+// and gcloud asset list --content-type=resource --format=json.
+// Resources that are not supported are returned as skipped.
+// Usage that is not part of the export (e.g. data in buckets) must be added manually.
+func Gcloud(files []string) (map[string]StructUsage, []Skipped, error) {
+	var resources []gcloudResource
+	var skipped []Skipped
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		var list []values
+		if err := json.Unmarshal(content, &list); err != nil {
+			return nil, nil, fmt.Errorf("gcloud JSON file '%s' could not be processed: %w", file, err)
+		}
+		for _, item := range list {
+			resource := gcloudResource{
+				Kind: item.str("kind"),
+				Name: item.str("selfLink"),
+				Data: item,
+			}
+			if assetType := item.str("assetType"); len(assetType) > 0 {
+				resource.Kind = gcloudAssetKinds[assetType]
+				resource.Name = item.str("name")
+				resource.Data = item.object("resource").object("data")
+				if resource.Data == nil {
+					skipped = append(skipped, Skipped{
+						Address: resource.Name,
+						Reason:  "asset without resource data (use --content-type=resource)",
+					})
+					continue
+				}
+				if len(resource.Kind) == 0 {
+					skipped = append(skipped, Skipped{
+						Address: resource.Name,
+						Reason:  fmt.Sprintf("asset type '%s' is not supported", assetType),
+					})
+					continue
+				}
+			}
+			if len(resource.Name) == 0 {
+				resource.Name = resource.Data.str("selfLink")
+			}
+			if match := gcloudProject.FindStringSubmatch(resource.Name); match != nil && match[1] != "_" {
+				resource.Project = match[1]
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	// Resources without project (e.g. buckets in asset list) belong to the project if there is only one
+	var names []string
+	for _, resource := range resources {
+		if len(resource.Project) > 0 && !slices.Contains(names, resource.Project) {
+			names = append(names, resource.Project)
+		}
+	}
+	if len(names) == 1 {
+		for i := range resources {
+			resources[i].Project = names[0]
+		}
+	}
+
+	// Disks by self link to add the type and size to the disks of the instances
+	disks := map[string]values{}
+	attached := map[string]bool{}
+	for _, resource := range resources {
+		if resource.Kind == "compute#disk" {
+			disks[resource.Data.str("selfLink")] = resource.Data
+		}
+	}
+
+	projects := map[string]StructUsage{}
+	for _, resource := range resources {
+		s := projects[resource.Project]
+		s.Project = resource.Project
+		data := resource.Data
+		switch resource.Kind {
+		case "compute#instance":
+			s.Instances = append(s.Instances, gcloudInstance(data, disks, attached))
+		case "compute#disk":
+			// Added after all instances
+		case "compute#address":
+			if data.str("addressType") != "INTERNAL" && data.str("status") == "RESERVED" {
+				skipped = append(skipped, Skipped{
+					Address: resource.Name,
+					Reason:  "static external IP address is not used by an instance",
+				})
+			}
+			// Used addresses are calculated as external IP of the instance
+		case "compute#router":
+			for _, nat := range data.blocks("nats") {
+				s.NatGateways = append(s.NatGateways, NatGateway{
+					Name:   nat.str("name"),
+					Region: data.name("region"),
+				})
+			}
+		case "compute#vpnTunnel":
+			s.VpnTunnels = append(s.VpnTunnels, VpnTunnel{
+				Name:   data.str("name"),
+				Region: data.name("region"),
+			})
+		case "storage#bucket":
+			region, class := bucketLocation(data.str("location"), data.str("storageClass"))
+			s.Buckets = append(s.Buckets, Bucket{
+				Name:   data.str("name"),
+				Class:  class,
+				Region: region,
+			})
+		default:
+			skipped = append(skipped, Skipped{
+				Address: resource.Name,
+				Reason:  fmt.Sprintf("kind '%s' is not supported", resource.Kind),
+			})
+		}
+		projects[resource.Project] = s
+	}
+
+	// Disks that are not attached to an instance
+	for _, resource := range resources {
+		if resource.Kind != "compute#disk" || attached[resource.Data.str("selfLink")] {
+			continue
+		}
+		s := projects[resource.Project]
+		s.Disks = append(s.Disks, Disk{
+			Name:       resource.Data.str("name"),
+			Type:       diskType(resource.Data.name("type")),
+			Region:     zoneRegion(resource.Data.name("zone")),
+			Data:       resource.Data.float("sizeGb"),
+			Iops:       resource.Data.float("provisionedIops"),
+			Throughput: resource.Data.float("provisionedThroughput"),
+		})
+		projects[resource.Project] = s
+	}
+	return projects, skipped, nil
+}
+
+func gcloudInstance(data values, disks map[string]values, attached map[string]bool) Instance {
+	scheduling := data.object("scheduling")
+	instance := Instance{
+		Name:       data.str("name"),
+		Type:       data.name("machineType"),
+		Region:     zoneRegion(data.name("zone")),
+		Spot:       scheduling.str("provisioningModel") == "SPOT" || scheduling.bool("preemptible"),
+		Terminated: data.str("status") == "TERMINATED",
+	}
+	for _, attachedDisk := range data.blocks("disks") {
+		// Operating system with paid license of boot disk
+		if attachedDisk.bool("boot") {
+			for _, license := range attachedDisk.list("licenses") {
+				if licenseOs := imageOs(license); len(licenseOs) > 0 {
+					instance.Os = licenseOs
+				}
+			}
+		}
+		disk := Disk{
+			Name: attachedDisk.str("deviceName"),
+			Data: attachedDisk.float("diskSizeGb"),
+		}
+		if attachedDisk.str("type") == "SCRATCH" {
+			disk.Type = "local"
+			disk.Data = 0
+			disk.Partitions = 1
+		} else if source, ok := disks[attachedDisk.str("source")]; ok {
+			attached[attachedDisk.str("source")] = true
+			disk.Name = source.str("name")
+			disk.Type = diskType(source.name("type"))
+			disk.Data = source.float("sizeGb")
+			disk.Iops = source.float("provisionedIops")
+			disk.Throughput = source.float("provisionedThroughput")
+		} else {
+			// Disk type is only part of the disks export
+			disk.Type = "balanced"
+		}
+		instance.Disks = append(instance.Disks, disk)
+	}
+	// One external IP address per access config
+	for _, networkInterface := range data.blocks("networkInterfaces") {
+		instance.ExternalIp += len(networkInterface.blocks("accessConfigs"))
+	}
+	return instance
+}
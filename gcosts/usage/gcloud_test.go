@@ -0,0 +1,156 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestGcloud(t *testing.T) {
+	webProject := StructUsage{
+		Project: "web-project",
+		Instances: []Instance{
+			{
+				Name:       "web",
+				Type:       "n2-standard-4",
+				Region:     "europe-west4",
+				Os:         "windows",
+				ExternalIp: 1,
+				Disks: []Disk{
+					{Name: "web", Type: "ssd", Data: 20},
+					{Name: "local-ssd-0", Type: "local", Partitions: 1},
+				},
+			},
+			{
+				Name:       "batch",
+				Type:       "e2-medium",
+				Region:     "europe-west4",
+				Spot:       true,
+				Terminated: true,
+				Disks:      []Disk{{Name: "batch", Type: "balanced", Data: 10}},
+			},
+		},
+		Disks:       []Disk{{Name: "data", Type: "hyperdisk-throughput", Region: "europe-west4", Data: 2048, Throughput: 180}},
+		NatGateways: []NatGateway{{Name: "nat-a", Region: "europe-west4"}, {Name: "nat-b", Region: "europe-west4"}},
+		VpnTunnels:  []VpnTunnel{{Name: "tunnel", Region: "europe-west4"}},
+	}
+	appProject := StructUsage{
+		Project: "app-project",
+		Instances: []Instance{
+			{
+				Name:   "app",
+				Type:   "e2-standard-2",
+				Region: "us-central1",
+				Spot:   true,
+				Os:     "rhel",
+				Disks:  []Disk{{Name: "app", Type: "balanced", Data: 50}},
+			},
+		},
+		Buckets:    []Bucket{{Name: "app-backup", Class: "coldline-multi", Region: "us-multi"}},
+		VpnTunnels: []VpnTunnel{{Name: "tunnel", Region: "us-central1"}},
+	}
+	computeSkipped := []Skipped{
+		{Address: "https://www.googleapis.com/compute/v1/projects/web-project/regions/europe-west4/addresses/unused", Reason: "static external IP address is not used by an instance"},
+		{Address: "https://www.googleapis.com/compute/v1/projects/web-project/global/firewalls/allow-ssh", Reason: "kind 'compute#firewall' is not supported"},
+	}
+	assetSkipped := []Skipped{
+		{Address: "//pubsub.googleapis.com/projects/app-project/topics/events", Reason: "asset type 'pubsub.googleapis.com/Topic' is not supported"},
+		{Address: "//compute.googleapis.com/projects/app-project/global/networks/default", Reason: "asset without resource data (use --content-type=resource)"},
+	}
+
+	tests := []struct {
+		name        string
+		files       []string
+		want        map[string]StructUsage
+		wantSkipped []Skipped
+	}{
+		{
+			name:        "compute exports",
+			files:       []string{"gcloud-instances.json", "gcloud-disks.json", "gcloud-network.json"},
+			want:        map[string]StructUsage{"web-project": webProject},
+			wantSkipped: computeSkipped,
+		},
+		{
+			name:        "asset export",
+			files:       []string{"gcloud-assets.json"},
+			want:        map[string]StructUsage{"app-project": appProject},
+			wantSkipped: assetSkipped,
+		},
+		{
+			name:  "bucket without project of multiple projects",
+			files: []string{"gcloud-assets.json", "gcloud-instances.json", "gcloud-disks.json", "gcloud-network.json"},
+			want: map[string]StructUsage{
+				"web-project": webProject,
+				"app-project": {
+					Project:    appProject.Project,
+					Instances:  appProject.Instances,
+					VpnTunnels: appProject.VpnTunnels,
+				},
+				"": {Buckets: appProject.Buckets},
+			},
+			wantSkipped: append(slices.Clone(assetSkipped), computeSkipped...),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var files []string
+			for _, file := range test.files {
+				files = append(files, filepath.Join("testdata", file))
+			}
+			projects, skipped, err := Gcloud(files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(slices.Sorted(maps.Keys(projects)), slices.Sorted(maps.Keys(test.want))) {
+				t.Fatalf("projects = %v, want %v", slices.Sorted(maps.Keys(projects)), slices.Sorted(maps.Keys(test.want)))
+			}
+			for project, want := range test.want {
+				if !reflect.DeepEqual(projects[project], want) {
+					t.Errorf("project %q = %+v, want %+v", project, projects[project], want)
+				}
+			}
+			if !reflect.DeepEqual(skipped, test.wantSkipped) {
+				t.Errorf("skipped = %+v, want %+v", skipped, test.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestGcloudError(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "instances.json")
+	if err := os.WriteFile(invalid, []byte(`{"kind": "compute#instance"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"not found", filepath.Join("testdata", "unknown.json")},
+		{"no list", invalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := Gcloud([]string{test.file}); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
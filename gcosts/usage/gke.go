@@ -0,0 +1,77 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"fmt"
+)
+
+// GkeRegionalZones is the number of zones of the node pools of a regional cluster
+const GkeRegionalZones = 3
+
+// Defaults of the node pools
+const (
+	gkeNodePoolName = "default-pool"
+	gkeDisk         = 100
+	gkeDiskType     = "balanced"
+)
+
+// Nodes expands the node pools of the cluster into instances with boot disks.
+// The instances are named CLUSTER/POOL-NUMBER.
+func (c GkeCluster) Nodes() []Instance {
+	zones := 1
+	if c.Regional {
+		zones = GkeRegionalZones
+	}
+	var instances []Instance
+	for _, nodePool := range c.NodePools {
+		poolName := nodePool.Name
+		if len(poolName) == 0 {
+			poolName = gkeNodePoolName
+		}
+		nodes := 1
+		if nodePool.Nodes != nil {
+			nodes = *nodePool.Nodes
+		}
+		disk := nodePool.Disk
+		if !(disk > 0) {
+			disk = gkeDisk
+		}
+		diskType := nodePool.DiskType
+		if len(diskType) == 0 {
+			diskType = gkeDiskType
+		}
+		for i := range nodes * zones {
+			name := fmt.Sprintf("%s/%s-%d", c.Name, poolName, i)
+			instances = append(instances, Instance{
+				Name:       name,
+				Type:       nodePool.Type,
+				Region:     c.Region,
+				Discount:   c.Discount,
+				Commitment: nodePool.Commitment,
+				Spot:       nodePool.Spot,
+				Disks: []Disk{{
+					Name:     name + "-boot",
+					Type:     diskType,
+					Region:   c.Region,
+					Discount: c.Discount,
+					Data:     disk,
+				}},
+			})
+		}
+	}
+	return instances
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"testing"
+)
+
+func TestGkeClusterNodes(t *testing.T) {
+	zero, two := 0, 2
+	tests := []struct {
+		name     string
+		regional bool
+		nodes    *int
+		want     int
+	}{
+		{"default one node", false, nil, 1},
+		{"no nodes", false, &zero, 0},
+		{"two nodes", false, &two, 2},
+		{"regional default one node per zone", true, nil, GkeRegionalZones},
+		{"regional no nodes", true, &zero, 0},
+		{"regional two nodes per zone", true, &two, 2 * GkeRegionalZones},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := GkeCluster{Name: "c", Regional: tt.regional, NodePools: []NodePool{{Type: "e2-standard-4", Nodes: tt.nodes}}}
+			nodes := cluster.Nodes()
+			if len(nodes) != tt.want {
+				t.Fatalf("nodes = %d, want %d", len(nodes), tt.want)
+			}
+			for _, node := range nodes {
+				if len(node.Disks) != 1 || node.Disks[0].Data != gkeDisk || node.Disks[0].Type != gkeDiskType {
+					t.Errorf("boot disk of node '%s' = %+v, want %.0f GiB %s", node.Name, node.Disks, float32(gkeDisk), gkeDiskType)
+				}
+			}
+		})
+	}
+}
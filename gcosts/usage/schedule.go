@@ -0,0 +1,132 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Time zones on systems without time zone database (e.g. Windows)
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+)
+
+// Schedule of an instance, e.g. business hours
+type Schedule struct {
+	Weekdays []string `yaml:",omitempty"`          // e.g. monday, tuesday or mon, tue (default all days)
+	Start    string   `yaml:",omitempty"`          // Time of day, e.g. 08:00 (default full day)
+	Stop     string   `yaml:",omitempty"`          // Time of day, e.g. 18:00 (before start to run overnight, equal to start for the full day)
+	TimeZone string   `yaml:"time-zone,omitempty"` // IANA time zone, e.g. Europe/Berlin
+}
+
+// Hours returns the average runtime of the schedule in hours per month.
+// With time zone the hours gained and lost by daylight saving time in the reference year are included.
+func (s Schedule) Hours() (float32, error) {
+	weekdays := map[time.Weekday]bool{}
+	for _, weekday := range s.Weekdays {
+		day, err := parseWeekday(weekday)
+		if err != nil {
+			return 0, err
+		}
+		weekdays[day] = true
+	}
+	if len(weekdays) == 0 {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			weekdays[day] = true
+		}
+	}
+	// Full day without start and stop (e.g. only on weekdays) or with equal start and stop
+	hoursDay := float64(24)
+	var start time.Time
+	if len(s.Start) > 0 || len(s.Stop) > 0 {
+		var err error
+		start, err = parseTimeOfDay(s.Start, "start")
+		if err != nil {
+			return 0, err
+		}
+		stop, err := parseTimeOfDay(s.Stop, "stop")
+		if err != nil {
+			return 0, err
+		}
+		if hours := stop.Sub(start).Hours(); hours > 0 {
+			hoursDay = hours
+		} else if hours < 0 {
+			// Overnight
+			hoursDay = hours + 24
+		}
+	}
+	// Average number of weeks per month
+	weeks := float64(pricing.HoursMonth) / (7 * 24)
+	hours := weeks * float64(len(weekdays)) * hoursDay
+	if len(s.TimeZone) > 0 {
+		location, err := time.LoadLocation(s.TimeZone)
+		if err != nil {
+			return 0, fmt.Errorf("time zone '%s' of schedule not found", s.TimeZone)
+		}
+		hours = hours + daylightSaving(location, weekdays, start, hoursDay)/12
+	}
+	return float32(hours), nil
+}
+
+// scheduleYear is the reference year for daylight saving time
+const scheduleYear = 2025
+
+// daylightSaving returns the hours gained (positive) or lost (negative) by daylight saving time
+// of the daily runtime in the reference year
+func daylightSaving(location *time.Location, weekdays map[time.Weekday]bool, start time.Time, hoursDay float64) float64 {
+	var hours float64
+	minutes := int(hoursDay * 60)
+	for day := time.Date(scheduleYear, time.January, 1, 0, 0, 0, 0, location); day.Year() == scheduleYear; day = day.AddDate(0, 0, 1) {
+		if !weekdays[day.Weekday()] {
+			continue
+		}
+		begin := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, location)
+		end := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute()+minutes, 0, 0, location)
+		hours = hours + end.Sub(begin).Hours() - hoursDay
+	}
+	return hours
+}
+
+func parseWeekday(weekday string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if strings.EqualFold(weekday, name) || strings.EqualFold(weekday, name[:3]) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("weekday '%s' of schedule not supported, use monday to sunday", weekday)
+}
+
+func parseTimeOfDay(timeOfDay string, name string) (time.Time, error) {
+	t, err := time.Parse("15:04", timeOfDay)
+	if err != nil {
+		return t, fmt.Errorf("%s time '%s' of schedule not supported, use HH:MM (e.g. 08:00)", name, timeOfDay)
+	}
+	return t, nil
+}
+
+// Runtime returns the runtime of the instance in hours per month from hours or schedule.
+// Returns 0 for a full month.
+func (i Instance) Runtime() (float32, error) {
+	scheduled := len(i.Schedule.Weekdays) > 0 || len(i.Schedule.Start) > 0 || len(i.Schedule.Stop) > 0 || len(i.Schedule.TimeZone) > 0
+	if !scheduled {
+		return i.Hours, nil
+	}
+	if i.Hours > 0 {
+		return 0, fmt.Errorf("instance '%s' has hours and schedule, use only one of them", i.Name)
+	}
+	return i.Schedule.Hours()
+}
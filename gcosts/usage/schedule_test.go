@@ -0,0 +1,98 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+)
+
+func TestScheduleHours(t *testing.T) {
+	weeks := float64(pricing.HoursMonth) / (7 * 24)
+	workdays := []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     float64
+		wantErr  bool
+	}{
+		{"all days", Schedule{Start: "08:00", Stop: "18:00"}, weeks * 7 * 10, false},
+		{"workdays", Schedule{Weekdays: workdays, Start: "08:00", Stop: "18:00"}, weeks * 5 * 10, false},
+		{"abbreviated weekdays", Schedule{Weekdays: []string{"mon", "Tue", "WED"}, Start: "08:00", Stop: "18:00"}, weeks * 3 * 10, false},
+		{"duplicate weekdays", Schedule{Weekdays: []string{"monday", "mon", "Monday"}, Start: "08:00", Stop: "18:00"}, weeks * 1 * 10, false},
+		{"unknown weekday", Schedule{Weekdays: []string{"mo"}, Start: "08:00", Stop: "18:00"}, 0, true},
+		{"minutes", Schedule{Weekdays: workdays, Start: "08:30", Stop: "17:15"}, weeks * 5 * 8.75, false},
+		{"overnight", Schedule{Weekdays: workdays, Start: "22:00", Stop: "06:00"}, weeks * 5 * 8, false},
+		{"overnight to midnight", Schedule{Start: "18:00", Stop: "00:00"}, weeks * 7 * 6, false},
+		{"equal start and stop", Schedule{Start: "08:00", Stop: "08:00"}, weeks * 7 * 24, false},
+		{"equal start and stop at midnight", Schedule{Weekdays: workdays, Start: "00:00", Stop: "00:00"}, weeks * 5 * 24, false},
+		{"weekdays without start and stop", Schedule{Weekdays: workdays}, weeks * 5 * 24, false},
+		{"weekend without start and stop", Schedule{Weekdays: []string{"saturday", "sunday"}, TimeZone: "Europe/Berlin"}, weeks * 2 * 24, false},
+		{"missing stop", Schedule{Start: "08:00"}, 0, true},
+		{"missing start", Schedule{Stop: "18:00"}, 0, true},
+		{"invalid start", Schedule{Start: "8 am", Stop: "18:00"}, 0, true},
+		{"unknown time zone", Schedule{Start: "08:00", Stop: "18:00", TimeZone: "Europe/Nowhere"}, 0, true},
+		{"time zone without daylight saving time", Schedule{Start: "08:00", Stop: "18:00", TimeZone: "Asia/Tokyo"}, weeks * 7 * 10, false},
+		// Clocks change on Sunday at 02:00 (spring) and 03:00 (autumn)
+		{"daylight saving time outside of runtime", Schedule{Weekdays: workdays, Start: "00:00", Stop: "06:00", TimeZone: "Europe/Berlin"}, weeks * 5 * 6, false},
+		{"daylight saving time within runtime", Schedule{Weekdays: []string{"sunday"}, Start: "00:00", Stop: "06:00", TimeZone: "Europe/Berlin"}, weeks * 1 * 6, false},
+		// Clocks change on Friday (spring) and Sunday (autumn)
+		{"one hour lost by daylight saving time", Schedule{Weekdays: []string{"friday"}, Start: "00:00", Stop: "06:00", TimeZone: "Asia/Jerusalem"}, weeks*1*6 - 1.0/12, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schedule.Hours()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("hours = %f, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(float64(got)-tt.want) > 0.001 {
+				t.Errorf("hours = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceRuntime(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance Instance
+		want     float32
+		wantErr  bool
+	}{
+		{"full month", Instance{}, 0, false},
+		{"hours", Instance{Hours: 100}, 100, false},
+		{"hours and schedule", Instance{Hours: 100, Schedule: Schedule{Start: "08:00", Stop: "18:00"}}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.instance.Runtime()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("runtime = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"fmt"
+)
+
+// SqlTier returns the tier of the instance.
+// Without tier the custom tier db-custom-CPU-MiB is built from the vCPUs and the memory.
+func (s SqlInstance) SqlTier() string {
+	if len(s.Tier) > 0 || s.Cpu == 0 {
+		return s.Tier
+	}
+	return fmt.Sprintf("db-custom-%d-%d", s.Cpu, int(s.Memory*1024))
+}
@@ -0,0 +1,233 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Skipped is a resource that is not converted to usage
+type Skipped struct {
+	Address string // e.g. "google_sql_database_instance.main"
+	Reason  string
+}
+
+type terraformPlan struct {
+	PlannedValues struct {
+		RootModule terraformModule `json:"root_module"`
+	} `json:"planned_values"`
+	Configuration struct {
+		ProviderConfig map[string]struct {
+			Name        string
+			Expressions map[string]struct {
+				ConstantValue any `json:"constant_value"`
+			}
+		} `json:"provider_config"`
+		RootModule terraformModuleConfig `json:"root_module"`
+	}
+}
+
+type terraformModule struct {
+	Address      string
+	Resources    []terraformResource
+	ChildModules []terraformModule `json:"child_modules"`
+}
+
+type terraformResource struct {
+	Address string
+	Mode    string
+	Type    string
+	Name    string
+	Values  map[string]any
+}
+
+type terraformModuleConfig struct {
+	Resources []struct {
+		Type        string
+		Expressions json.RawMessage
+	}
+	ModuleCalls map[string]struct {
+		Module terraformModuleConfig
+	} `json:"module_calls"`
+}
+
+var terraformReference = regexp.MustCompile(`google_compute_address\.[A-Za-z0-9_-]+`)
+var terraformIndex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// TerraformPlan converts the resources of a Terraform plan (terraform show -json) to usage.
+// Resources that are not supported are returned as skipped.
+// Usage that is not part of the plan (e.g. data in buckets) must be added manually.
+func TerraformPlan(file string) (StructUsage, []Skipped, error) {
+	s := StructUsage{}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return s, nil, err
+	}
+	plan := terraformPlan{}
+	if err := json.Unmarshal(content, &plan); err != nil {
+		return s, nil, fmt.Errorf("plan JSON file '%s' could not be processed: %w", file, err)
+	}
+
+	// Default region of the Google provider
+	for _, provider := range plan.Configuration.ProviderConfig {
+		if provider.Name != "google" {
+			continue
+		}
+		if region, ok := provider.Expressions["region"].ConstantValue.(string); ok {
+			s.Region = region
+		}
+	}
+
+	// Static IP addresses used by instances
+	used := map[string]bool{}
+	terraformAddressReferences(plan.Configuration.RootModule, "", used)
+
+	var skipped []Skipped
+	for _, resource := range terraformResources(plan.PlannedValues.RootModule) {
+		if resource.Mode != "managed" {
+			continue
+		}
+		attributes := values(resource.Values)
+		if len(s.Project) == 0 {
+			s.Project = attributes.str("project")
+		}
+		switch resource.Type {
+		case "google_compute_instance":
+			s.Instances = append(s.Instances, terraformInstance(attributes))
+		case "google_compute_disk":
+			s.Disks = append(s.Disks, Disk{
+				Name:       attributes.str("name"),
+				Type:       diskType(attributes.name("type")),
+				Region:     zoneRegion(attributes.str("zone")),
+				Data:       attributes.float("size"),
+				Iops:       attributes.float("provisioned_iops"),
+				Throughput: attributes.float("provisioned_throughput"),
+			})
+		case "google_storage_bucket":
+			region, class := bucketLocation(attributes.str("location"), attributes.str("storage_class"))
+			s.Buckets = append(s.Buckets, Bucket{
+				Name:   attributes.str("name"),
+				Class:  class,
+				Region: region,
+			})
+		case "google_compute_router_nat":
+			s.NatGateways = append(s.NatGateways, NatGateway{
+				Name:   attributes.str("name"),
+				Region: attributes.str("region"),
+			})
+		case "google_compute_vpn_tunnel":
+			s.VpnTunnels = append(s.VpnTunnels, VpnTunnel{
+				Name:   attributes.str("name"),
+				Region: attributes.str("region"),
+			})
+		case "google_compute_address":
+			if strings.EqualFold(attributes.str("address_type"), "INTERNAL") {
+				// Internal IP addresses are free
+				continue
+			}
+			if !used[terraformModulePrefix(resource.Address)+"google_compute_address."+resource.Name] {
+				skipped = append(skipped, Skipped{
+					Address: resource.Address,
+					Reason:  "static external IP address is not used by an instance",
+				})
+			}
+			// Used addresses are calculated as external IP of the instance
+		default:
+			skipped = append(skipped, Skipped{
+				Address: resource.Address,
+				Reason:  fmt.Sprintf("resource type '%s' is not supported", resource.Type),
+			})
+		}
+	}
+	return s, skipped, nil
+}
+
+// terraformResources returns the resources of the module and all child modules
+func terraformResources(module terraformModule) []terraformResource {
+	resources := module.Resources
+	for _, child := range module.ChildModules {
+		resources = append(resources, terraformResources(child)...)
+	}
+	return resources
+}
+
+// terraformAddressReferences collects the references of instances to static IP addresses
+func terraformAddressReferences(module terraformModuleConfig, prefix string, used map[string]bool) {
+	for _, resource := range module.Resources {
+		if resource.Type != "google_compute_instance" {
+			continue
+		}
+		for _, reference := range terraformReference.FindAllString(string(resource.Expressions), -1) {
+			used[prefix+reference] = true
+		}
+	}
+	for name, call := range module.ModuleCalls {
+		terraformAddressReferences(call.Module, prefix+"module."+name+".", used)
+	}
+}
+
+// terraformModulePrefix returns the module part of the address of a static IP address, e.g. "module.network."
+func terraformModulePrefix(address string) string {
+	i := strings.Index(address, "google_compute_address.")
+	if i < 0 {
+		return ""
+	}
+	return terraformIndex.ReplaceAllString(address[:i], "")
+}
+
+func terraformInstance(attributes values) Instance {
+	instance := Instance{
+		Name:       attributes.str("name"),
+		Type:       attributes.name("machine_type"),
+		Region:     zoneRegion(attributes.str("zone")),
+		Terminated: attributes.str("desired_status") == "TERMINATED",
+	}
+	scheduling := attributes.block("scheduling")
+	instance.Spot = scheduling.str("provisioning_model") == "SPOT" || scheduling.bool("preemptible")
+
+	// Boot disk
+	bootDisk := attributes.block("boot_disk").block("initialize_params")
+	image := bootDisk.str("image")
+	instance.Os = imageOs(image)
+	size := bootDisk.float("size")
+	if !(size > 0) {
+		size = 10 // Default size of most public images
+	}
+	instance.Disks = append(instance.Disks, Disk{
+		Name: instance.Name + "-boot",
+		Type: diskType(bootDisk.name("type")),
+		Data: size,
+	})
+
+	// Local SSDs
+	for i := range attributes.blocks("scratch_disk") {
+		instance.Disks = append(instance.Disks, Disk{
+			Name:       fmt.Sprintf("%s-local-ssd-%d", instance.Name, i),
+			Type:       "local",
+			Partitions: 1,
+		})
+	}
+
+	// One external IP address per access config
+	for _, networkInterface := range attributes.blocks("network_interface") {
+		instance.ExternalIp += len(networkInterface.blocks("access_config"))
+	}
+	return instance
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTerraformPlan(t *testing.T) {
+	s, skipped, err := TerraformPlan(filepath.Join("testdata", "plan.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Project != "my-project" || s.Region != "europe-west4" {
+		t.Errorf("project = %q, region = %q, want my-project, europe-west4", s.Project, s.Region)
+	}
+
+	wantInstances := []Instance{
+		{
+			Name:       "web",
+			Type:       "n2-standard-4",
+			Region:     "europe-west4",
+			Spot:       true,
+			Os:         "rhel",
+			ExternalIp: 1,
+			Disks: []Disk{
+				{Name: "web-boot", Type: "ssd", Data: 20},
+				{Name: "web-local-ssd-0", Type: "local", Partitions: 1},
+				{Name: "web-local-ssd-1", Type: "local", Partitions: 1},
+			},
+		},
+		{
+			Name:       "worker-0",
+			Type:       "e2-medium",
+			Region:     "us-central1",
+			Spot:       true,
+			Terminated: true,
+			Disks:      []Disk{{Name: "worker-0-boot", Type: "hdd", Data: 10}},
+		},
+	}
+	if !reflect.DeepEqual(s.Instances, wantInstances) {
+		t.Errorf("instances = %+v, want %+v", s.Instances, wantInstances)
+	}
+
+	wantDisks := []Disk{
+		{Name: "data", Type: "hyperdisk-balanced", Region: "europe-west4", Data: 500, Iops: 6000, Throughput: 290},
+		{Name: "default", Type: "hdd", Region: "us-central1", Data: 100},
+	}
+	if !reflect.DeepEqual(s.Disks, wantDisks) {
+		t.Errorf("disks = %+v, want %+v", s.Disks, wantDisks)
+	}
+
+	wantBuckets := []Bucket{
+		{Name: "archive", Class: "archiv-multi", Region: "europe-multi"},
+		{Name: "dual", Class: "nearline-dual", Region: "eur4"},
+		{Name: "regional", Class: "standard", Region: "europe-west4"},
+	}
+	if !reflect.DeepEqual(s.Buckets, wantBuckets) {
+		t.Errorf("buckets = %+v, want %+v", s.Buckets, wantBuckets)
+	}
+
+	wantNatGateways := []NatGateway{{Name: "nat", Region: "us-central1"}}
+	if !reflect.DeepEqual(s.NatGateways, wantNatGateways) {
+		t.Errorf("NAT gateways = %+v, want %+v", s.NatGateways, wantNatGateways)
+	}
+	wantVpnTunnels := []VpnTunnel{{Name: "tunnel", Region: "us-central1"}}
+	if !reflect.DeepEqual(s.VpnTunnels, wantVpnTunnels) {
+		t.Errorf("VPN tunnels = %+v, want %+v", s.VpnTunnels, wantVpnTunnels)
+	}
+
+	// Used and internal IP addresses and data sources are not skipped
+	wantSkipped := []Skipped{
+		{Address: "google_compute_address.unused", Reason: "static external IP address is not used by an instance"},
+		{Address: "google_sql_database_instance.main", Reason: "resource type 'google_sql_database_instance' is not supported"},
+	}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Errorf("skipped = %+v, want %+v", skipped, wantSkipped)
+	}
+}
+
+func TestTerraformPlanError(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(invalid, []byte("project: not a plan\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"not found", filepath.Join("testdata", "unknown.json")},
+		{"invalid JSON", invalid},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := TerraformPlan(test.file); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
+
+func TestTerraformModulePrefix(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"google_compute_address.web", ""},
+		{"module.network.google_compute_address.web", "module.network."},
+		{"module.network[0].module.ip[\"a\"].google_compute_address.web", "module.network.module.ip."},
+		{"google_compute_instance.web", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			if got := terraformModulePrefix(test.address); got != test.want {
+				t.Errorf("terraformModulePrefix(%q) = %q, want %q", test.address, got, test.want)
+			}
+		})
+	}
+}
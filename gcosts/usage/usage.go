@@ -16,6 +16,9 @@ limitations under the License.
 package usage
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"os"
 
 	"github.com/pterm/pterm"
@@ -23,76 +26,207 @@ import (
 )
 
 type Instance struct {
-	Name       string
-	Type       string
-	Region     string
-	Discount   float32
-	Commitment int
-	Spot       bool
-	Os         string
-	ExternalIp int `yaml:"external-ip"`
-	Disks      []Disk
-	Buckets    []Bucket
-	Terminated bool
+	Name         string        `yaml:",omitempty"`
+	Type         string        `yaml:",omitempty"`
+	Region       string        `yaml:",omitempty"`
+	Discount     float32       `yaml:",omitempty"`
+	Commitment   int           `yaml:",omitempty"`
+	Spot         bool          `yaml:",omitempty"`
+	Os           string        `yaml:",omitempty"`
+	ExternalIp   int           `yaml:"external-ip,omitempty"`
+	Accelerators []Accelerator `yaml:",omitempty"`
+	Disks        []Disk        `yaml:",omitempty"`
+	Buckets      []Bucket      `yaml:",omitempty"`
+	Terminated   bool          `yaml:",omitempty"`
+	Hours        float32       `yaml:",omitempty"` // Runtime per month (default full month)
+	Schedule     Schedule      `yaml:",omitempty"`
+}
+
+// Accelerator is a GPU attached to an instance
+type Accelerator struct {
+	Type  string `yaml:",omitempty"`
+	Count int    `yaml:",omitempty"` // Default 1
 }
 
 type Disk struct {
-	Name     string
-	Type     string
-	Region   string
-	Discount float32
-	Data     float32
+	Name       string  `yaml:",omitempty"`
+	Type       string  `yaml:",omitempty"`
+	Region     string  `yaml:",omitempty"`
+	Discount   float32 `yaml:",omitempty"`
+	Data       float32 `yaml:",omitempty"`
+	Partitions int     `yaml:",omitempty"` // Local SSD partitions instead of data
+	Iops       float32 `yaml:",omitempty"` // Provisioned IOPS
+	Throughput float32 `yaml:",omitempty"` // Provisioned throughput in MiB/s
 }
 
 type Bucket struct {
-	Name      string
-	Class     string
-	Region    string
-	Discount  float32
-	Data      float32
-	Retrieval float32
+	Name              string  `yaml:",omitempty"`
+	Class             string  `yaml:",omitempty"`
+	Region            string  `yaml:",omitempty"`
+	Discount          float32 `yaml:",omitempty"`
+	Data              float32 `yaml:",omitempty"`
+	Retrieval         float32 `yaml:",omitempty"`
+	ClassA            float32 `yaml:"class-a,omitempty"`             // Class A operations per month
+	ClassB            float32 `yaml:"class-b,omitempty"`             // Class B operations per month
+	EarlyDeletion     float32 `yaml:"early-deletion,omitempty"`      // GiB deleted before the minimum storage duration per month
+	EarlyDeletionDays float32 `yaml:"early-deletion-days,omitempty"` // Days the data was stored before the deletion
+	Replication       float32 `yaml:",omitempty"`                    // GiB written to a dual-region or multi-region per month
 }
 
 type VpnTunnel struct {
-	Name     string
-	Region   string
-	Discount float32
+	Name     string  `yaml:",omitempty"`
+	Region   string  `yaml:",omitempty"`
+	Discount float32 `yaml:",omitempty"`
 }
 
 type NatGateway struct {
-	Name     string
-	Region   string
-	Discount float32
-	Data     float32
+	Name     string  `yaml:",omitempty"`
+	Region   string  `yaml:",omitempty"`
+	Discount float32 `yaml:",omitempty"`
+	Data     float32 `yaml:",omitempty"`
+}
+
+// LoadBalancer is a Cloud Load Balancing load balancer with forwarding rules
+type LoadBalancer struct {
+	Name     string  `yaml:",omitempty"`
+	Type     string  `yaml:",omitempty"` // Default external
+	Region   string  `yaml:",omitempty"`
+	Discount float32 `yaml:",omitempty"`
+	Rules    int     `yaml:",omitempty"` // Number of forwarding rules (default 1)
+	Data     float32 `yaml:",omitempty"` // Processed data in GiB
+}
+
+// GkeCluster is a Google Kubernetes Engine cluster in Standard or Autopilot mode
+type GkeCluster struct {
+	Name       string     `yaml:",omitempty"`
+	Region     string     `yaml:",omitempty"`
+	Discount   float32    `yaml:",omitempty"`
+	Mode       string     `yaml:",omitempty"`                  // standard (default) or autopilot
+	Regional   bool       `yaml:",omitempty"`                  // Nodes of the node pools in three zones
+	NodePools  []NodePool `yaml:"node-pools,omitempty"`        // Standard
+	Cpu        float32    `yaml:",omitempty"`                  // Autopilot vCPU requests
+	Memory     float32    `yaml:",omitempty"`                  // Autopilot memory requests in GiB
+	Storage    float32    `yaml:"ephemeral-storage,omitempty"` // Autopilot ephemeral storage requests in GiB
+	Commitment int        `yaml:",omitempty"`                  // Autopilot
+	Spot       bool       `yaml:",omitempty"`                  // Autopilot
+}
+
+// NodePool is a node pool of a GKE Standard cluster
+type NodePool struct {
+	Name       string  `yaml:",omitempty"`
+	Type       string  `yaml:",omitempty"` // Machine type
+	Nodes      *int    `yaml:",omitempty"` // Nodes per zone (default 1, 0 for a node pool without nodes)
+	Commitment int     `yaml:",omitempty"`
+	Spot       bool    `yaml:",omitempty"`
+	Disk       float32 `yaml:",omitempty"`          // Boot disk size in GiB (default 100)
+	DiskType   string  `yaml:"disk-type,omitempty"` // Boot disk type (default balanced)
+}
+
+// SqlInstance is a Cloud SQL instance with storage and backups
+type SqlInstance struct {
+	Name             string  `yaml:",omitempty"`
+	Region           string  `yaml:",omitempty"`
+	Discount         float32 `yaml:",omitempty"`
+	Database         string  `yaml:",omitempty"`                  // mysql (default), postgres or sqlserver
+	Edition          string  `yaml:",omitempty"`                  // enterprise (default) or enterprise-plus, SQL Server: standard (default), enterprise, web or express
+	Tier             string  `yaml:",omitempty"`                  // e.g. db-f1-micro or db-custom-2-7680
+	Cpu              int     `yaml:",omitempty"`                  // vCPUs of the custom tier if no tier is set
+	Memory           float32 `yaml:",omitempty"`                  // Memory in GiB of the custom tier if no tier is set
+	HighAvailability bool    `yaml:"high-availability,omitempty"` // Regional instance with standby
+	Storage          float32 `yaml:",omitempty"`                  // Storage in GiB
+	StorageType      string  `yaml:"storage-type,omitempty"`      // ssd (default) or hdd
+	Backup           float32 `yaml:",omitempty"`                  // Backups in GiB
+	Commitment       int     `yaml:",omitempty"`
+}
+
+// Serverless is a Cloud Run service or a Cloud Run function
+type Serverless struct {
+	Name         string  `yaml:",omitempty"`
+	Region       string  `yaml:",omitempty"`
+	Discount     float32 `yaml:",omitempty"`
+	Billing      string  `yaml:",omitempty"`              // request (default) or instance
+	Cpu          float32 `yaml:",omitempty"`              // vCPUs per instance (default 1)
+	Memory       float32 `yaml:",omitempty"`              // Memory per instance in GiB (default 0.5)
+	Requests     float32 `yaml:",omitempty"`              // Requests per month
+	Duration     float32 `yaml:",omitempty"`              // Average request duration in milliseconds
+	Concurrency  int     `yaml:",omitempty"`              // Concurrent requests per instance (default 80)
+	MinInstances int     `yaml:"min-instances,omitempty"` // Minimum number of instances
+}
+
+// Bigquery is the on-demand analysis, capacity and storage of BigQuery
+type Bigquery struct {
+	Name           string  `yaml:",omitempty"`
+	Region         string  `yaml:",omitempty"` // Region or multi-region (europe-multi, us-multi)
+	Discount       float32 `yaml:",omitempty"`
+	Analysis       float32 `yaml:",omitempty"`                  // On-demand TiB scanned per month
+	Edition        string  `yaml:",omitempty"`                  // standard (default), enterprise or enterprise-plus
+	BaselineSlots  int     `yaml:"baseline-slots,omitempty"`    // Slots charged the whole month
+	MaxSlots       int     `yaml:"max-slots,omitempty"`         // Maximum slots with autoscaling
+	AutoscaleHours float32 `yaml:"autoscale-hours,omitempty"`   // Hours per month with the maximum slots
+	Commitment     int     `yaml:",omitempty"`                  // Of the baseline slots (enterprise and enterprise-plus)
+	StorageBilling string  `yaml:"storage-billing,omitempty"`   // logical (default) or physical
+	Active         float32 `yaml:"active-storage,omitempty"`    // GiB
+	LongTerm       float32 `yaml:"long-term-storage,omitempty"` // GiB
+	Streaming      float32 `yaml:",omitempty"`                  // GiB streaming inserts per month
 }
 
 type Monitoring struct {
-	Name     string
-	Region   string
-	Discount float32
-	Data     float32
+	Name     string  `yaml:",omitempty"`
+	Region   string  `yaml:",omitempty"`
+	Discount float32 `yaml:",omitempty"`
+	Data     float32 `yaml:",omitempty"`
 }
 
 type Traffic struct {
-	Name      string
-	Region    string
-	Discount  float32
-	World     float32
-	China     float32
-	Australia float32
+	Name        string        `yaml:",omitempty"`
+	Region      string        `yaml:",omitempty"`
+	Discount    float32       `yaml:",omitempty"`
+	World       float32       `yaml:",omitempty"`
+	China       float32       `yaml:",omitempty"`
+	Australia   float32       `yaml:",omitempty"`
+	InterZone   float32       `yaml:"inter-zone,omitempty"`   // GiB between zones in the region
+	InterRegion []InterRegion `yaml:"inter-region,omitempty"` // GiB to other regions
+}
+
+// InterRegion is the traffic from the region of the traffic to the destination region
+type InterRegion struct {
+	Region string  `yaml:",omitempty"` // Destination region
+	Data   float32 `yaml:",omitempty"`
+}
+
+// Budget is the monthly budget of the usage file and the project
+type Budget struct {
+	File    float32 `yaml:",omitempty"` // Maximum costs of the usage file
+	Project float32 `yaml:",omitempty"` // Maximum costs of the project of the usage file (all usage files)
+	Growth  float32 `yaml:",omitempty"` // Maximum growth of the costs of the usage file in percent compared to the baseline
+}
+
+// Commitment is a flexible (spend-based) committed use discount
+type Commitment struct {
+	Name   string  `yaml:",omitempty"`
+	Term   int     `yaml:",omitempty"` // 1 or 3 years
+	Amount float32 `yaml:",omitempty"` // On-demand spend per hour
 }
 
 type StructUsage struct {
-	Region      string
-	Project     string
-	Discount    float32
-	Instances   []Instance
-	Disks       []Disk
-	Buckets     []Bucket
-	VpnTunnels  []VpnTunnel  `yaml:"vpn-tunnels"`
-	NatGateways []NatGateway `yaml:"nat-gateways"`
-	Monitoring  []Monitoring
-	Traffic     []Traffic
+	Region         string         `yaml:",omitempty"`
+	Project        string         `yaml:",omitempty"`
+	Discount       float32        `yaml:",omitempty"`
+	BillingAccount string         `yaml:"billing-account,omitempty"` // For the aggregation of tiered usage
+	Budget         Budget         `yaml:",omitempty"`
+	Instances      []Instance     `yaml:",omitempty"`
+	Disks          []Disk         `yaml:",omitempty"`
+	Buckets        []Bucket       `yaml:",omitempty"`
+	VpnTunnels     []VpnTunnel    `yaml:"vpn-tunnels,omitempty"`
+	NatGateways    []NatGateway   `yaml:"nat-gateways,omitempty"`
+	LoadBalancers  []LoadBalancer `yaml:"load-balancers,omitempty"`
+	GkeClusters    []GkeCluster   `yaml:"gke-clusters,omitempty"`
+	SqlInstances   []SqlInstance  `yaml:"cloud-sql,omitempty"`
+	Serverless     []Serverless   `yaml:",omitempty"`
+	Bigquery       []Bigquery     `yaml:",omitempty"`
+	Monitoring     []Monitoring   `yaml:",omitempty"`
+	Traffic        []Traffic      `yaml:",omitempty"`
+	Commitments    []Commitment   `yaml:",omitempty"`
 }
 
 func readUsageYmlFile(filepath string) []byte {
@@ -108,13 +242,23 @@ func Yml(file string) StructUsage {
 	filecontent := readUsageYmlFile(file)
 
 	s := StructUsage{}
-	err := yaml.Unmarshal([]byte(filecontent), &s)
-	if err != nil {
+	// Unknown fields (e.g. typos) are errors
+	decoder := yaml.NewDecoder(bytes.NewReader(filecontent))
+	decoder.KnownFields(true)
+	err := decoder.Decode(&s)
+	if err != nil && !errors.Is(err, io.EOF) {
 		pterm.Error.Println("Usage YAML file could not be processed.\n" +
 			"Please check the file structure and make sure that it is not another YAML file (like the price list).\n\n" +
+			"Run 'gcosts validate' to list all problems.\n\n" +
 			"For more help, please see:\n" +
 			"  <https://github.com/Cyclenerd/google-cloud-pricing-cost-calculator/blob/master/usage/README.md>")
-		pterm.Error.Println(err)
+		problems := schemaProblems(file, filecontent)
+		for _, problem := range problems {
+			pterm.Error.Println(problem)
+		}
+		if len(problems) == 0 {
+			pterm.Error.Println(err)
+		}
 		os.Exit(8)
 	}
 
@@ -0,0 +1,658 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an error in a usage file
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+type validator struct {
+	file      string
+	pricing   pricing.StructPricing
+	problems  []Problem
+	positions map[string]*yaml.Node // Nodes by path, e.g. "instances.0.type"
+}
+
+// Validate checks the usage file strictly and returns all problems at once:
+// unknown fields, wrong types, values out of range and
+// resources (region, machine type, disk type, storage class, license) not found in the pricing information.
+// The default region is used for resources without region.
+// An error is only returned if the file can not be read or is not valid YAML.
+func Validate(file string, pricingYml pricing.StructPricing, defaultRegion string) (StructUsage, []Problem, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return StructUsage{}, nil, err
+	}
+	v := &validator{
+		file:      file,
+		pricing:   pricingYml,
+		positions: map[string]*yaml.Node{},
+	}
+	s, err := v.parse(content)
+	if err != nil {
+		return s, nil, err
+	}
+	v.crossCheck(s, defaultRegion)
+	return s, v.sorted(), nil
+}
+
+// schemaProblems returns the unknown fields and wrong types of the usage file content
+func schemaProblems(file string, content []byte) []Problem {
+	v := &validator{
+		file:      file,
+		positions: map[string]*yaml.Node{},
+	}
+	if _, err := v.parse(content); err != nil {
+		return nil
+	}
+	return v.sorted()
+}
+
+// parse checks the structure of the content and decodes it
+func (v *validator) parse(content []byte) (StructUsage, error) {
+	s := StructUsage{}
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return s, err
+	}
+	v.walk(&node, reflect.TypeOf(s), "")
+	// Type errors are already reported
+	_ = node.Decode(&s)
+	return s, nil
+}
+
+// sorted returns the problems sorted by position
+func (v *validator) sorted() []Problem {
+	slices.SortStableFunc(v.problems, func(a, b Problem) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return v.problems
+}
+
+func (v *validator) problem(node *yaml.Node, format string, a ...any) {
+	problem := Problem{File: v.file, Message: fmt.Sprintf(format, a...)}
+	if node != nil {
+		problem.Line = node.Line
+		problem.Column = node.Column
+	}
+	v.problems = append(v.problems, problem)
+}
+
+// walk checks the fields and types of the node against the type of the usage structure
+func (v *validator) walk(node *yaml.Node, t reflect.Type, path string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			v.walk(content, t, path)
+		}
+		return
+	case yaml.AliasNode:
+		v.walk(node.Alias, t, path)
+		return
+	}
+	v.positions[path] = node
+	if node.Tag == "!!null" {
+		return
+	}
+	name := fieldName(path)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.problem(node, "'%s' must be a mapping", name)
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				v.problem(key, "unknown field '%s' in %s", key.Value, strings.ToLower(t.Name()))
+				continue
+			}
+			v.walk(value, field.Type, joinPath(path, key.Value))
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.problem(node, "'%s' must be a list", name)
+			return
+		}
+		for i, item := range node.Content {
+			v.walk(item, t.Elem(), joinPath(path, strconv.Itoa(i)))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.problem(node, "'%s' must be true or false", name)
+		}
+	case reflect.Int, reflect.Float32:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			v.problem(node, "'%s' must be a number", name)
+			return
+		}
+		number, err := strconv.ParseFloat(node.Value, 64)
+		switch {
+		case err != nil:
+			v.problem(node, "'%s' must be a number", name)
+		case t.Kind() == reflect.Int && node.Tag != "!!int":
+			v.problem(node, "'%s' must be an integer", name)
+		case name == "commitment" && number != 0 && number != 1 && number != 3:
+			v.problem(node, "'commitment' must be 0, 1 or 3")
+		case number < 0:
+			v.problem(node, "'%s' must not be negative", name)
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			v.problem(node, "'%s' must be a string", name)
+		}
+	}
+}
+
+// yamlFields returns the fields of the structure by YAML key
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if len(key) == 0 {
+			key = strings.ToLower(field.Name)
+		}
+		fields[key] = field
+	}
+	return fields
+}
+
+func joinPath(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// fieldName returns the last key of the path which is not a list index
+func fieldName(path string) string {
+	keys := strings.Split(path, ".")
+	for i := len(keys) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(keys[i]); err != nil {
+			return keys[i]
+		}
+	}
+	return path
+}
+
+// crossCheck checks the resources against the pricing information
+func (v *validator) crossCheck(s StructUsage, defaultRegion string) {
+	region := v.region("", s.Region, defaultRegion)
+	for i, instance := range s.Instances {
+		path := joinPath("instances", strconv.Itoa(i))
+		instanceRegion := v.region(path, instance.Region, region)
+		if len(instance.Type) == 0 {
+			v.problem(v.positions[path], "machine type 'type' missing")
+		} else if _, err := pricing.CheckComputeInstance(v.pricing, instance.Type); err != nil {
+			v.problem(v.positions[path+".type"], "%s", err)
+		} else if _, err := pricing.CostComputeInstance(v.pricing, instance.Type, instanceRegion); err != nil && len(instanceRegion) > 0 {
+			v.problem(v.positions[path+".type"], "%s", err)
+		} else if len(instance.Os) > 0 {
+			if _, err := pricing.CostComputeLicense(v.pricing, instance.Type, instance.Os); err != nil {
+				v.problem(v.positions[path+".os"], "%s", err)
+			}
+		}
+		if hours, err := instance.Runtime(); err != nil {
+			v.problem(v.positions[path+".schedule"], "%s", err)
+		} else if hours > pricing.HoursMonth {
+			v.problem(v.positions[path+".hours"], "'hours' must not be more than %v hours per month", pricing.HoursMonth)
+		}
+		for j, accelerator := range instance.Accelerators {
+			v.checkAccelerator(joinPath(path, "accelerators."+strconv.Itoa(j)), accelerator, instance.Type, instanceRegion)
+		}
+		for j, disk := range instance.Disks {
+			v.checkDisk(joinPath(path, "disks."+strconv.Itoa(j)), disk, instanceRegion)
+		}
+		for j, bucket := range instance.Buckets {
+			v.checkBucket(joinPath(path, "buckets."+strconv.Itoa(j)), bucket, instanceRegion)
+		}
+	}
+	for i, disk := range s.Disks {
+		v.checkDisk(joinPath("disks", strconv.Itoa(i)), disk, region)
+	}
+	for i, bucket := range s.Buckets {
+		v.checkBucket(joinPath("buckets", strconv.Itoa(i)), bucket, region)
+	}
+	for i, commitment := range s.Commitments {
+		path := joinPath("commitments", strconv.Itoa(i))
+		if commitment.Term != 1 && commitment.Term != 3 {
+			v.problem(v.position(path, "term"), "'term' of commitment must be 1 or 3")
+		}
+		if !(commitment.Amount > 0) {
+			v.problem(v.position(path, "amount"), "'amount' of commitment must be more than 0")
+		}
+	}
+	for i, vpnTunnel := range s.VpnTunnels {
+		v.region(joinPath("vpn-tunnels", strconv.Itoa(i)), vpnTunnel.Region, region)
+	}
+	for i, natGateway := range s.NatGateways {
+		v.region(joinPath("nat-gateways", strconv.Itoa(i)), natGateway.Region, region)
+	}
+	for i, loadBalancer := range s.LoadBalancers {
+		v.checkLoadBalancer(joinPath("load-balancers", strconv.Itoa(i)), loadBalancer, region)
+	}
+	for i, cluster := range s.GkeClusters {
+		v.checkGkeCluster(joinPath("gke-clusters", strconv.Itoa(i)), cluster, region)
+	}
+	for i, instance := range s.SqlInstances {
+		v.checkSqlInstance(joinPath("cloud-sql", strconv.Itoa(i)), instance, region)
+	}
+	for i, serverless := range s.Serverless {
+		v.checkServerless(joinPath("serverless", strconv.Itoa(i)), serverless, region)
+	}
+	for i, bigquery := range s.Bigquery {
+		v.checkBigquery(joinPath("bigquery", strconv.Itoa(i)), bigquery, region)
+	}
+	for i, monitoring := range s.Monitoring {
+		v.region(joinPath("monitoring", strconv.Itoa(i)), monitoring.Region, region)
+	}
+	for i, traffic := range s.Traffic {
+		path := joinPath("traffic", strconv.Itoa(i))
+		trafficRegion := v.region(path, traffic.Region, region)
+		if traffic.InterZone > 0 && len(trafficRegion) > 0 {
+			if _, err := pricing.CostComputeNetworkTrafficInterZone(v.pricing, trafficRegion); err != nil {
+				v.problem(v.position(path, "inter-zone"), "%s", err)
+			}
+		}
+		for j, interRegion := range traffic.InterRegion {
+			interRegionPath := joinPath(path, "inter-region."+strconv.Itoa(j))
+			if len(interRegion.Region) == 0 {
+				v.problem(v.positions[interRegionPath], "destination region 'region' missing")
+			} else if destination := v.region(interRegionPath, interRegion.Region, ""); len(destination) > 0 && len(trafficRegion) > 0 {
+				if _, err := pricing.CostComputeNetworkTrafficInterRegion(v.pricing, trafficRegion, destination); err != nil {
+					v.problem(v.position(interRegionPath, "region"), "%s", err)
+				}
+			}
+		}
+	}
+}
+
+// position returns the node of the key or of the path if the key is not set
+func (v *validator) position(path string, key string) *yaml.Node {
+	if node, ok := v.positions[joinPath(path, key)]; ok {
+		return node
+	}
+	return v.positions[path]
+}
+
+// region checks the region of the resource and returns the region or the default region.
+// An empty string is returned if the region is not found.
+func (v *validator) region(path string, inputRegion string, defaultRegion string) string {
+	if len(inputRegion) == 0 {
+		return defaultRegion
+	}
+	if err := pricing.CheckRegion(v.pricing, inputRegion); err != nil {
+		v.problem(v.positions[joinPath(path, "region")], "%s", err)
+		return ""
+	}
+	return inputRegion
+}
+
+func (v *validator) checkAccelerator(path string, accelerator Accelerator, machineType string, region string) {
+	if len(accelerator.Type) == 0 {
+		v.problem(v.positions[path], "GPU type 'type' missing")
+	} else if _, err := pricing.CheckComputeGpu(v.pricing, accelerator.Type); err != nil {
+		v.problem(v.positions[path+".type"], "%s", err)
+	} else if err := pricing.CheckComputeGpuMachineType(accelerator.Type, machineType); err != nil && len(machineType) > 0 {
+		v.problem(v.positions[path+".type"], "%s", err)
+	} else if _, err := pricing.CostComputeGpu(v.pricing, accelerator.Type, region); err != nil && len(region) > 0 {
+		v.problem(v.positions[path+".type"], "%s", err)
+	}
+}
+
+func (v *validator) checkLoadBalancer(path string, loadBalancer LoadBalancer, defaultRegion string) {
+	region := v.region(path, loadBalancer.Region, defaultRegion)
+	lbType := loadBalancer.Type
+	if len(lbType) == 0 {
+		lbType = "external"
+	}
+	if _, err := pricing.CheckComputeNetworkLb(v.pricing, lbType); err != nil {
+		v.problem(v.position(path, "type"), "%s", err)
+	} else if _, err := pricing.CostComputeNetworkLbRule(v.pricing, lbType, region); err != nil && len(region) > 0 {
+		v.problem(v.position(path, "region"), "%s", err)
+	}
+}
+
+func (v *validator) checkDisk(path string, disk Disk, defaultRegion string) {
+	region := v.region(path, disk.Region, defaultRegion)
+	if len(disk.Type) == 0 {
+		v.problem(v.positions[path], "disk type 'type' missing")
+	} else if _, err := pricing.CheckComputeDisk(v.pricing, disk.Type); err != nil {
+		v.problem(v.positions[path+".type"], "%s", err)
+	} else if _, err := pricing.CostComputeDisk(v.pricing, disk.Type, region); err != nil && len(region) > 0 {
+		v.problem(v.positions[path+".type"], "%s", err)
+	} else {
+		v.checkDiskPerformance(path, disk, region)
+	}
+}
+
+// checkDiskPerformance checks the partitions and the provisioned performance against the limits of the disk type
+func (v *validator) checkDiskPerformance(path string, disk Disk, region string) {
+	resource, _ := pricing.CheckComputeDisk(v.pricing, disk.Type)
+	if disk.Partitions > 0 {
+		if !(resource.Partition > 0) {
+			v.problem(v.position(path, "partitions"), "disk type '%s' has no partitions", disk.Type)
+		} else if disk.Data > 0 {
+			v.problem(v.position(path, "data"), "set either 'data' or 'partitions' of disk")
+		}
+	} else if resource.Partition > 0 && disk.Data > 0 && math.Mod(float64(disk.Data), float64(resource.Partition)) != 0 {
+		v.problem(v.position(path, "data"), "'data' of disk type '%s' must be a multiple of %v GiB", disk.Type, resource.Partition)
+	}
+	if disk.Iops > 0 {
+		if !(resource.Iops.Max > 0) {
+			v.problem(v.position(path, "iops"), "disk type '%s' does not support provisioned IOPS", disk.Type)
+		} else if disk.Iops < resource.Iops.Min || disk.Iops > resource.Iops.Max {
+			v.problem(v.position(path, "iops"), "'iops' of disk type '%s' must be between %v and %v", disk.Type, resource.Iops.Min, resource.Iops.Max)
+		} else if _, err := pricing.CostComputeDiskIops(v.pricing, disk.Type, region); err != nil && len(region) > 0 && disk.Iops > resource.Iops.Included {
+			v.problem(v.position(path, "iops"), "%s", err)
+		}
+	}
+	if disk.Throughput > 0 {
+		if !(resource.Throughput.Max > 0) {
+			v.problem(v.position(path, "throughput"), "disk type '%s' does not support provisioned throughput", disk.Type)
+		} else if disk.Throughput < resource.Throughput.Min || disk.Throughput > resource.Throughput.Max {
+			v.problem(v.position(path, "throughput"), "'throughput' of disk type '%s' must be between %v and %v MiB/s", disk.Type, resource.Throughput.Min, resource.Throughput.Max)
+		} else if _, err := pricing.CostComputeDiskThroughput(v.pricing, disk.Type, region); err != nil && len(region) > 0 && disk.Throughput > resource.Throughput.Included {
+			v.problem(v.position(path, "throughput"), "%s", err)
+		}
+	}
+}
+
+func (v *validator) checkGkeCluster(path string, cluster GkeCluster, defaultRegion string) {
+	region := v.region(path, cluster.Region, defaultRegion)
+	mode := cluster.Mode
+	if len(mode) == 0 {
+		mode = pricing.GkeModeStandard
+	}
+	if err := pricing.CheckGkeMode(mode); err != nil {
+		v.problem(v.position(path, "mode"), "%s", err)
+		return
+	}
+	if len(region) > 0 {
+		if _, err := pricing.CostGkeCluster(v.pricing, region); err != nil {
+			v.problem(v.positions[path], "%s", err)
+		}
+	}
+	if mode == pricing.GkeModeAutopilot {
+		if len(cluster.NodePools) > 0 {
+			v.problem(v.position(path, "node-pools"), "Autopilot cluster has no 'node-pools'")
+		}
+		if cluster.Regional {
+			v.problem(v.position(path, "regional"), "Autopilot cluster is always regional")
+		}
+		if len(region) > 0 {
+			if _, err := pricing.CostGkeAutopilotCpu(v.pricing, region); err != nil && cluster.Cpu > 0 {
+				v.problem(v.position(path, "cpu"), "%s", err)
+			}
+			if _, err := pricing.CostGkeAutopilotRam(v.pricing, region); err != nil && cluster.Memory > 0 {
+				v.problem(v.position(path, "memory"), "%s", err)
+			}
+			if _, err := pricing.CostGkeAutopilotStorage(v.pricing, region); err != nil && cluster.Storage > 0 {
+				v.problem(v.position(path, "ephemeral-storage"), "%s", err)
+			}
+		}
+		return
+	}
+	for _, key := range []string{"cpu", "memory", "ephemeral-storage", "commitment", "spot"} {
+		if node, ok := v.positions[joinPath(path, key)]; ok {
+			v.problem(node, "'%s' only applies to Autopilot clusters, set it on the node pools", key)
+		}
+	}
+	for j, nodePool := range cluster.NodePools {
+		nodePoolPath := joinPath(path, "node-pools."+strconv.Itoa(j))
+		if len(nodePool.Type) == 0 {
+			v.problem(v.positions[nodePoolPath], "machine type 'type' missing")
+		} else if _, err := pricing.CheckComputeInstance(v.pricing, nodePool.Type); err != nil {
+			v.problem(v.position(nodePoolPath, "type"), "%s", err)
+		} else if _, err := pricing.CostComputeInstance(v.pricing, nodePool.Type, region); err != nil && len(region) > 0 {
+			v.problem(v.position(nodePoolPath, "type"), "%s", err)
+		}
+		if nodePool.Nodes != nil && *nodePool.Nodes < 0 {
+			v.problem(v.position(nodePoolPath, "nodes"), "number of nodes must not be negative")
+		}
+		diskType := nodePool.DiskType
+		if len(diskType) == 0 {
+			diskType = gkeDiskType
+		}
+		if _, err := pricing.CheckComputeDisk(v.pricing, diskType); err != nil {
+			v.problem(v.position(nodePoolPath, "disk-type"), "%s", err)
+		} else if _, err := pricing.CostComputeDisk(v.pricing, diskType, region); err != nil && len(region) > 0 {
+			v.problem(v.position(nodePoolPath, "disk-type"), "%s", err)
+		}
+	}
+}
+
+func (v *validator) checkSqlInstance(path string, instance SqlInstance, defaultRegion string) {
+	region := v.region(path, instance.Region, defaultRegion)
+	database, edition := pricing.ReturnSqlDatabase(instance.Database, instance.Edition)
+	if err := pricing.CheckSqlDatabase(database, edition); err != nil {
+		key := "edition"
+		var resourceErr *pricing.ResourceError
+		if errors.As(err, &resourceErr) && resourceErr.Name == database {
+			key = "database"
+		}
+		v.problem(v.position(path, key), "%s", err)
+		return
+	}
+	if len(instance.Tier) > 0 && (instance.Cpu > 0 || instance.Memory > 0) {
+		v.problem(v.position(path, "tier"), "set either 'tier' or 'cpu' and 'memory'")
+		return
+	}
+	tier := instance.SqlTier()
+	tierKey := "tier"
+	if len(instance.Tier) == 0 {
+		tierKey = "cpu"
+	}
+	if len(tier) == 0 {
+		v.problem(v.positions[path], "tier 'tier' or 'cpu' and 'memory' missing")
+	} else if _, err := pricing.CheckSqlTier(v.pricing, database, edition, tier); err != nil {
+		v.problem(v.position(path, tierKey), "%s", err)
+	} else if _, err := pricing.CostSqlInstance(v.pricing, database, edition, tier, region); err != nil && len(region) > 0 {
+		v.problem(v.position(path, tierKey), "%s", err)
+	}
+	if len(region) == 0 {
+		return
+	}
+	if database == "sqlserver" && edition != "express" {
+		if _, err := pricing.CostSqlLicense(v.pricing, edition, region); err != nil {
+			v.problem(v.position(path, "edition"), "%s", err)
+		}
+	}
+	if instance.Storage > 0 {
+		storageType := instance.StorageType
+		if len(storageType) == 0 {
+			storageType = "ssd"
+		}
+		if _, err := pricing.CostSqlStorage(v.pricing, storageType, region); err != nil {
+			v.problem(v.position(path, "storage-type"), "%s", err)
+		}
+	}
+	if instance.Backup > 0 {
+		if _, err := pricing.CostSqlStorage(v.pricing, "backup", region); err != nil {
+			v.problem(v.position(path, "backup"), "%s", err)
+		}
+	}
+}
+
+func (v *validator) checkServerless(path string, serverless Serverless, defaultRegion string) {
+	region := v.region(path, serverless.Region, defaultRegion)
+	billing := pricing.ReturnServerlessBilling(serverless.Billing)
+	if _, err := pricing.CheckServerlessBilling(v.pricing, billing); err != nil {
+		v.problem(v.position(path, "billing"), "%s", err)
+		return
+	}
+	if serverless.Cpu > 8 {
+		v.problem(v.position(path, "cpu"), "'cpu' must not be more than 8 vCPUs")
+	}
+	if serverless.Memory > 32 {
+		v.problem(v.position(path, "memory"), "'memory' must not be more than 32 GiB")
+	}
+	if serverless.Concurrency > 1000 {
+		v.problem(v.position(path, "concurrency"), "'concurrency' must not be more than 1000")
+	}
+	if serverless.Requests > 0 && !(serverless.Duration > 0) {
+		v.problem(v.position(path, "requests"), "average request duration 'duration' missing")
+	}
+	if len(region) == 0 {
+		return
+	}
+	if _, err := pricing.CostServerlessCpu(v.pricing, billing, region); err != nil {
+		v.problem(v.positions[path], "%s", err)
+	}
+	if billing == pricing.ServerlessBillingRequest && serverless.Requests > 0 {
+		if _, err := pricing.CostServerlessRequests(v.pricing, billing, region); err != nil {
+			v.problem(v.position(path, "requests"), "%s", err)
+		}
+	}
+	if billing == pricing.ServerlessBillingRequest && serverless.MinInstances > 0 {
+		if _, err := pricing.CostServerlessIdleCpu(v.pricing, billing, region); err != nil {
+			v.problem(v.position(path, "min-instances"), "%s", err)
+		}
+	}
+}
+
+func (v *validator) checkBigquery(path string, bigquery Bigquery, defaultRegion string) {
+	region := v.region(path, bigquery.Region, defaultRegion)
+	if bigquery.Analysis > 0 && len(region) > 0 {
+		if _, err := pricing.CostBigqueryAnalysis(v.pricing, region); err != nil {
+			v.problem(v.position(path, "analysis"), "%s", err)
+		}
+	}
+	if bigquery.BaselineSlots > 0 || bigquery.MaxSlots > 0 {
+		edition := bigquery.Edition
+		if len(edition) == 0 {
+			edition = pricing.BigqueryEditionStandard
+		}
+		if _, err := pricing.CheckBigqueryEdition(v.pricing, edition); err != nil {
+			v.problem(v.position(path, "edition"), "%s", err)
+		} else if _, err := pricing.CostBigqueryEdition(v.pricing, edition, region); err != nil && len(region) > 0 {
+			v.problem(v.position(path, "edition"), "%s", err)
+		}
+		if edition == pricing.BigqueryEditionStandard && bigquery.Commitment > 0 {
+			v.problem(v.position(path, "commitment"), "no commitments for the BigQuery Standard edition")
+		}
+		for _, slots := range []struct {
+			key   string
+			value int
+		}{
+			{"baseline-slots", bigquery.BaselineSlots},
+			{"max-slots", bigquery.MaxSlots},
+		} {
+			if slots.value%pricing.BigquerySlots != 0 {
+				v.problem(v.position(path, slots.key), "'%s' must be a multiple of %d", slots.key, pricing.BigquerySlots)
+			}
+		}
+		if bigquery.MaxSlots > 0 && bigquery.MaxSlots < bigquery.BaselineSlots {
+			v.problem(v.position(path, "max-slots"), "'max-slots' must not be less than 'baseline-slots'")
+		}
+		if bigquery.AutoscaleHours > pricing.HoursMonth {
+			v.problem(v.position(path, "autoscale-hours"), "'autoscale-hours' must not be more than %.0f hours", pricing.HoursMonth)
+		}
+	} else if len(bigquery.Edition) > 0 {
+		v.problem(v.position(path, "edition"), "slots 'baseline-slots' or 'max-slots' missing")
+	}
+	billing := bigquery.StorageBilling
+	if len(billing) == 0 {
+		billing = pricing.BigqueryStorageLogical
+	}
+	if err := pricing.CheckBigqueryStorageBilling(billing); err != nil {
+		v.problem(v.position(path, "storage-billing"), "%s", err)
+		return
+	}
+	if len(region) == 0 {
+		return
+	}
+	storageTypes := pricing.ReturnBigqueryStorageTypes(billing)
+	for i, storage := range []struct {
+		key  string
+		data float32
+	}{
+		{"active-storage", bigquery.Active},
+		{"long-term-storage", bigquery.LongTerm},
+	} {
+		if storage.data > 0 {
+			if _, err := pricing.CostBigqueryStorage(v.pricing, storageTypes[i], region); err != nil {
+				v.problem(v.position(path, storage.key), "%s", err)
+			}
+		}
+	}
+	if bigquery.Streaming > 0 {
+		if _, err := pricing.CostBigqueryStreaming(v.pricing, region); err != nil {
+			v.problem(v.position(path, "streaming"), "%s", err)
+		}
+	}
+}
+
+func (v *validator) checkBucket(path string, bucket Bucket, defaultRegion string) {
+	region := v.region(path, bucket.Region, defaultRegion)
+	if len(bucket.Class) == 0 {
+		v.problem(v.positions[path], "storage class 'class' missing")
+	} else if _, err := pricing.CheckStorageBucket(v.pricing, bucket.Class); err != nil {
+		v.problem(v.positions[path+".class"], "%s", err)
+	} else if _, err := pricing.CostStorageBucket(v.pricing, bucket.Class, region); err != nil && len(region) > 0 {
+		v.problem(v.positions[path+".class"], "%s", err)
+	} else if len(region) > 0 {
+		if bucket.ClassA > 0 {
+			if _, err := pricing.CostStorageOperationsClassA(v.pricing, bucket.Class, region); err != nil {
+				v.problem(v.position(path, "class-a"), "%s", err)
+			}
+		}
+		if bucket.ClassB > 0 {
+			if _, err := pricing.CostStorageOperationsClassB(v.pricing, bucket.Class, region); err != nil {
+				v.problem(v.position(path, "class-b"), "%s", err)
+			}
+		}
+		if bucket.EarlyDeletion > 0 {
+			if _, err := pricing.CostStorageEarlyDeletion(v.pricing, bucket.Class, region); err != nil {
+				v.problem(v.position(path, "early-deletion"), "%s", err)
+			}
+		}
+		if bucket.Replication > 0 {
+			if _, err := pricing.CostStorageReplication(v.pricing, bucket.Class, region); err != nil {
+				v.problem(v.position(path, "replication"), "%s", err)
+			}
+		}
+	}
+}
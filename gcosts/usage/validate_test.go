@@ -0,0 +1,314 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Cyclenerd/google-cloud-pricing-cost-calculator/gcosts/pricing"
+	"gopkg.in/yaml.v3"
+)
+
+const testValidatePricing = `
+region:
+  europe-west4: {location: Netherlands, continent: europe}
+  us-central1: {location: Iowa, continent: north-america}
+compute:
+  instance:
+    e2-small:
+      cost:
+        europe-west4: {hour: 0.02, month: 14.6}
+  storage:
+    ssd:
+      cost:
+        europe-west4: {month: 0.2}
+`
+
+// testValidate writes the usage file and returns the problems as "line:column: message"
+func testValidate(t *testing.T, pricingYaml string, usageYaml string) []string {
+	t.Helper()
+	var pricingYml pricing.StructPricing
+	if err := yaml.Unmarshal([]byte(pricingYaml), &pricingYml); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "usage.yml")
+	if err := os.WriteFile(file, []byte(usageYaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, problems, err := Validate(file, pricingYml, "europe-west4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, problem := range problems {
+		if problem.File != file {
+			t.Errorf("file = %q, want %q", problem.File, file)
+		}
+		got = append(got, problem.String()[len(dir)+len("/usage.yml:"):])
+	}
+	return got
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage string
+		want  []string
+	}{
+		{
+			name: "valid",
+			usage: `
+project: my-project
+instances:
+  - name: web
+    type: e2-small
+    commitment: 3
+    disks:
+      - {name: data, type: ssd, data: 100}
+commitments:
+  - {name: flex, term: 1, amount: 10}
+`,
+		},
+		{
+			name: "unknown fields",
+			usage: `
+projekt: my-project
+instances:
+  - name: web
+    typ: e2-small
+    disks:
+      - {name: data, type: ssd, size: 100}
+`,
+			want: []string{
+				"2:1: unknown field 'projekt' in structusage",
+				"4:5: machine type 'type' missing",
+				"5:5: unknown field 'typ' in instance",
+				"7:33: unknown field 'size' in disk",
+			},
+		},
+		{
+			name: "type errors",
+			usage: `
+discount: ten
+instances:
+  - type: e2-small
+    spot: yes please
+    external-ip: 1.5
+    hours: -1
+    disks: {name: data, type: ssd}
+buckets: bucket
+`,
+			want: []string{
+				"2:11: 'discount' must be a number",
+				"5:11: 'spot' must be true or false",
+				"6:18: 'external-ip' must be an integer",
+				"7:12: 'hours' must not be negative",
+				"8:12: 'disks' must be a list",
+				"9:10: 'buckets' must be a list",
+			},
+		},
+		{
+			name: "commitment 0, 1 or 3",
+			usage: `
+instances:
+  - {type: e2-small, commitment: 0}
+  - {type: e2-small, commitment: 1}
+  - {type: e2-small, commitment: 2}
+  - {type: e2-small, commitment: 3}
+  - {type: e2-small, commitment: -1}
+  - {type: e2-small, commitment: 1.0}
+`,
+			want: []string{
+				"5:34: 'commitment' must be 0, 1 or 3",
+				"7:34: 'commitment' must be 0, 1 or 3",
+				"8:34: 'commitment' must be an integer",
+			},
+		},
+		{
+			name: "flexible commitment term and amount",
+			usage: `
+commitments:
+  - name: flex
+    term: 2
+  - {name: flex, term: 3, amount: 0}
+`,
+			want: []string{
+				"3:5: 'amount' of commitment must be more than 0",
+				"4:11: 'term' of commitment must be 1 or 3",
+				"5:35: 'amount' of commitment must be more than 0",
+			},
+		},
+		{
+			name: "pricing information",
+			usage: `
+region: us-central1
+instances:
+  - name: web
+    type: e2-micro
+  - name: app
+    type: e2-small
+    region: europe-west4
+    disks:
+      - {type: balanced}
+  - name: db
+    type: e2-small
+disks:
+  - {type: ssd, region: europe-west9}
+`,
+			want: []string{
+				"5:11: Google Compute Engine machine type 'e2-micro' not found!",
+				"10:16: Google Compute Engine storage disk type 'balanced' not found!",
+				"12:11: GCE machine type 'e2-small' in region 'us-central1' not found!",
+				"14:25: Google Cloud region 'europe-west9' not found!",
+			},
+		},
+		{
+			name: "position of aliases",
+			usage: `
+instances:
+  - &web
+    name: web
+    type: e2-small
+    spot: 1
+  - *web
+`,
+			want: []string{
+				"6:11: 'spot' must be true or false",
+				"6:11: 'spot' must be true or false",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := testValidate(t, testValidatePricing, test.usage)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("problems =\n%q\nwant\n%q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateError(t *testing.T) {
+	var pricingYml pricing.StructPricing
+	file := filepath.Join(t.TempDir(), "usage.yml")
+	if _, _, err := Validate(file, pricingYml, ""); err == nil {
+		t.Error("file not found: want error")
+	}
+	if err := os.WriteFile(file, []byte("instances: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Validate(file, pricingYml, ""); err == nil {
+		t.Error("invalid YAML: want error")
+	}
+}
+
+func TestValidateDisk(t *testing.T) {
+	diskPricing := `
+region:
+  us-central1: {}
+  europe-west4: {}
+compute:
+  storage:
+    local:
+      partition: 375
+      cost:
+        us-central1: {month: 0.08}
+    hyperdisk-balanced:
+      iops: {min: 3000, max: 160000, included: 3000, cost: {us-central1: {month: 0.005}}}
+      throughput: {min: 140, max: 2400, included: 140, cost: {us-central1: {month: 0.04}}}
+      cost:
+        us-central1: {month: 0.08}
+        europe-west4: {month: 0.09}
+    ssd:
+      cost:
+        us-central1: {month: 0.17}
+`
+	tests := []struct {
+		name string
+		disk string // Flow mapping of the disk in us-central1
+		want []string
+	}{
+		{name: "partitions", disk: "{type: local, partitions: 2}"},
+		{name: "data multiple of the partition size", disk: "{type: local, data: 750}"},
+		{name: "data not multiple of the partition size", disk: "{type: local, data: 500}", want: []string{"'data' of disk type 'local' must be a multiple of 375 GiB"}},
+		// Scratch disks of older imports with data and partitions
+		{name: "data and partitions", disk: "{type: local, data: 375, partitions: 1}", want: []string{"set either 'data' or 'partitions' of disk"}},
+		{name: "disk type without partitions", disk: "{type: ssd, partitions: 1}", want: []string{"disk type 'ssd' has no partitions"}},
+		{name: "minimum IOPS and throughput", disk: "{type: hyperdisk-balanced, data: 100, iops: 3000, throughput: 140}"},
+		{name: "maximum IOPS and throughput", disk: "{type: hyperdisk-balanced, data: 100, iops: 160000, throughput: 2400}"},
+		{name: "IOPS below minimum", disk: "{type: hyperdisk-balanced, data: 100, iops: 2999}", want: []string{"'iops' of disk type 'hyperdisk-balanced' must be between 3000 and 160000"}},
+		{name: "IOPS above maximum", disk: "{type: hyperdisk-balanced, data: 100, iops: 160001}", want: []string{"'iops' of disk type 'hyperdisk-balanced' must be between 3000 and 160000"}},
+		{name: "throughput below minimum", disk: "{type: hyperdisk-balanced, data: 100, throughput: 139}", want: []string{"'throughput' of disk type 'hyperdisk-balanced' must be between 140 and 2400 MiB/s"}},
+		{name: "throughput above maximum", disk: "{type: hyperdisk-balanced, data: 100, throughput: 2401}", want: []string{"'throughput' of disk type 'hyperdisk-balanced' must be between 140 and 2400 MiB/s"}},
+		{name: "no provisioned IOPS", disk: "{type: ssd, data: 100, iops: 3000}", want: []string{"disk type 'ssd' does not support provisioned IOPS"}},
+		{name: "no provisioned throughput", disk: "{type: ssd, data: 100, throughput: 140}", want: []string{"disk type 'ssd' does not support provisioned throughput"}},
+		{name: "included performance without price in region", disk: "{type: hyperdisk-balanced, region: europe-west4, data: 100, iops: 3000, throughput: 140}"},
+		{name: "IOPS without price in region", disk: "{type: hyperdisk-balanced, region: europe-west4, data: 100, iops: 3001}", want: []string{"GCE storage disk type with provisioned IOPS 'hyperdisk-balanced' in region 'europe-west4' not found!"}},
+		{name: "throughput without price in region", disk: "{type: hyperdisk-balanced, region: europe-west4, data: 100, throughput: 141}", want: []string{"GCE storage disk type with provisioned throughput 'hyperdisk-balanced' in region 'europe-west4' not found!"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testValidate(t, diskPricing, "region: us-central1\ndisks:\n  - "+tt.disk+"\n")
+			var messages []string
+			for _, problem := range got {
+				// Position of the disk or of the key of the problem in line 3
+				if !strings.HasPrefix(problem, "3:") {
+					t.Errorf("problem %q not in line 3", problem)
+				}
+				_, message, _ := strings.Cut(problem, ": ")
+				messages = append(messages, message)
+			}
+			if !slices.Equal(messages, tt.want) {
+				t.Errorf("problems = %q, want %q", messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateImportedLocalSsd(t *testing.T) {
+	// Scratch disks of the Terraform and gcloud importers are Local SSD partitions
+	s, _, err := TerraformPlan(filepath.Join("testdata", "plan.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := yaml.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var disks []string
+	for _, problem := range testValidate(t, `
+region:
+  europe-west4: {}
+compute:
+  storage:
+    local:
+      partition: 375
+      cost:
+        europe-west4: {month: 0.08}
+`, string(content)) {
+		if strings.Contains(problem, "'local'") || strings.Contains(problem, "partitions") || strings.Contains(problem, "'data'") {
+			disks = append(disks, problem)
+		}
+	}
+	if len(disks) > 0 {
+		t.Errorf("problems of Local SSDs = %q, want none", disks)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright © 2023 Nils Knieling <https://github.com/Cyclenerd>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package usage
+
+import (
+	"strconv"
+	"strings"
+)
+
+// values are the attribute values of a resource, nested block or object in JSON files
+type values map[string]any
+
+func (v values) str(key string) string {
+	value, _ := v[key].(string)
+	return value
+}
+
+// name returns the last part of an URL, e.g. "n2-standard-4" for "zones/europe-west4-a/machineTypes/n2-standard-4"
+func (v values) name(key string) string {
+	value := v.str(key)
+	return value[strings.LastIndex(value, "/")+1:]
+}
+
+// float returns numbers and numbers in strings (e.g. "diskSizeGb": "10")
+func (v values) float(key string) float32 {
+	switch value := v[key].(type) {
+	case float64:
+		return float32(value)
+	case string:
+		f, _ := strconv.ParseFloat(value, 32)
+		return float32(f)
+	}
+	return 0
+}
+
+func (v values) bool(key string) bool {
+	value, _ := v[key].(bool)
+	return value
+}
+
+// list returns the strings of a list
+func (v values) list(key string) []string {
+	var list []string
+	items, _ := v[key].([]any)
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// object returns the nested object
+func (v values) object(key string) values {
+	object, _ := v[key].(map[string]any)
+	return object
+}
+
+// blocks returns the nested blocks or lists of objects
+func (v values) blocks(key string) []values {
+	var blocks []values
+	list, _ := v[key].([]any)
+	for _, item := range list {
+		if block, ok := item.(map[string]any); ok {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// block returns the first nested block
+func (v values) block(key string) values {
+	blocks := v.blocks(key)
+	if len(blocks) == 0 {
+		return values{}
+	}
+	return blocks[0]
+}
+
+// zoneRegion returns the region of a zone, e.g. "europe-west4" for "europe-west4-a"
+func zoneRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 && len(zone)-i == 2 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// diskType returns the disk type of gcosts for the API name of the disk type
+func diskType(apiType string) string {
+	switch apiType {
+	case "", "pd-standard":
+		return "hdd"
+	case "local-ssd":
+		return "local"
+	}
+	return strings.TrimPrefix(apiType, "pd-")
+}
+
+// imageOs returns the operating system with paid license of the image or license
+func imageOs(image string) string {
+	image = strings.ToLower(image)
+	switch {
+	case strings.Contains(image, "sles") && strings.Contains(image, "sap"):
+		return "sles-sap"
+	case strings.Contains(image, "sles"):
+		return "sles"
+	case strings.Contains(image, "rhel") && strings.Contains(image, "sap"):
+		return "rhel-sap"
+	case strings.Contains(image, "rhel"):
+		return "rhel"
+	case strings.Contains(image, "windows"):
+		return "windows"
+	}
+	return ""
+}
+
+// bucketLocation returns the region and storage class of gcosts for the location and storage class of a bucket
+func bucketLocation(location string, storageClass string) (string, string) {
+	var class string
+	switch strings.ToUpper(storageClass) {
+	case "NEARLINE":
+		class = "nearline"
+	case "COLDLINE":
+		class = "coldline"
+	case "ARCHIVE":
+		class = "archiv"
+	case "DURABLE_REDUCED_AVAILABILITY":
+		class = "dra"
+	default:
+		// STANDARD, MULTI_REGIONAL and REGIONAL
+		class = "standard"
+	}
+	region := strings.ToLower(location)
+	switch {
+	case region == "eu":
+		return "europe-multi", class + "-multi"
+	case region == "us" || region == "asia":
+		return region + "-multi", class + "-multi"
+	case !strings.Contains(region, "-"):
+		// Predefined dual-region, e.g. eur4
+		return region, class + "-dual"
+	}
+	return region, class
+}